@@ -21,6 +21,13 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if themesPath := core.Config.ThemesPath(); len(themesPath) > 0 {
+		err = core.LoadThemes(themesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	err = chronometer.Default().LoadJob(new(jobs.EquityPriceFetch))
 	if err != nil {
 		log.Fatal(err)
@@ -1,20 +1,62 @@
 package controller
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
 	"github.com/blendlabs/go-util"
 	"github.com/blendlabs/go-web"
+	"github.com/wcharczuk/chart-service/server/core"
 	"github.com/wcharczuk/chart-service/server/viewmodel"
 	"github.com/wcharczuk/go-chart"
 )
 
+const (
+	// maxChartCacheBytes is the most memory the chart cache holds rendered charts in, and maxCachedChartBytes the
+	// largest chart it caches; larger charts (i.e. big animations) are rendered every time.
+	maxChartCacheBytes  = 64 << 20
+	maxCachedChartBytes = 2 << 20
+)
+
+// chartCache holds rendered charts by their normalized parameters, so refreshing a dashboard doesn't fetch
+// and draw every chart again.
+var chartCache = core.NewRenderCache(maxChartCacheBytes, maxCachedChartBytes)
+
 // Charts is the controller that generates charts.
 type Charts struct{}
 
 func (cc Charts) getChartAction(rc *web.Ctx) web.Result {
+	return cc.chart(rc, rc)
+}
+
+// postChartAction renders a chart described by a json spec, validated against `viewmodel.ChartSpecSchema`.
+func (cc Charts) postChartAction(rc *web.Ctx) web.Result {
+	body, err := rc.PostBody()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	spec, err := viewmodel.ParseChartSpec(body)
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	return cc.chart(rc, spec.Ctx(rc))
+}
+
+func (cc Charts) getChartSchemaAction(rc *web.Ctx) web.Result {
+	return rc.JSON().Result(viewmodel.ChartSpecSchema)
+}
+
+// chart renders a chart with the parameters read from `params` to the response of `rc`. Rendered charts are
+// cached until their ttl, then served again without being drawn if the data is unchanged.
+func (cc Charts) chart(rc, params *web.Ctx) web.Result {
 	cv := &viewmodel.Chart{}
-	err := cv.Parse(rc)
+	err := cv.Parse(params)
 	if err != nil {
-		return rc.API().InternalError(err)
+		return rc.API().BadRequest(err.Error())
 	}
 	err = cv.ParsePeriod()
 	if err != nil {
@@ -24,6 +66,12 @@ func (cc Charts) getChartAction(rc *web.Ctx) web.Result {
 	if err != nil {
 		return rc.API().BadRequest(err.Error())
 	}
+
+	key := cv.CacheKey()
+	if entry, ok := chartCache.Get(key); ok && entry.IsFresh(time.Now()) {
+		return cc.cached(rc, entry)
+	}
+
 	err = cv.FetchTickers()
 	if err != nil {
 		return rc.API().BadRequest(err.Error())
@@ -32,35 +80,200 @@ func (cc Charts) getChartAction(rc *web.Ctx) web.Result {
 	if err != nil {
 		return rc.API().InternalError(err)
 	}
+	err = cv.ValidatePriceData()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
 
-	graph, err := cv.CreateChart()
+	lastModified := cv.LastModified()
+	etag := cv.ETag(key)
+	expires := time.Now().Add(cv.CacheTTL())
+	if entry, ok := chartCache.Get(key); ok && entry.ETag == etag {
+		// the data hasn't changed since the chart was rendered; serve it for another ttl.
+		revalidated := *entry
+		revalidated.Expires = expires
+		chartCache.Set(key, &revalidated)
+		return cc.cached(rc, &revalidated)
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err = cc.renderChart(rc, buffer, cv)
 	if err != nil {
 		return rc.API().InternalError(err)
 	}
+	entry := &core.RenderCacheEntry{
+		ContentType:  rc.Response.Header().Get("Content-Type"),
+		Body:         buffer.Bytes(),
+		ETag:         etag,
+		LastModified: lastModified,
+		Expires:      expires,
+	}
+	chartCache.Set(key, entry)
+	return cc.cached(rc, entry)
+}
 
-	if util.String.CaseInsensitiveEquals(cv.Format, "png") {
-		rc.Response.Header().Set("Content-Type", "image/png")
-		err := graph.Render(chart.PNG, rc.Response)
+// renderChart writes the chart to `w` in its format; as an image, as its series or as a vega-lite spec.
+func (cc Charts) renderChart(rc *web.Ctx, w io.Writer, cv *viewmodel.Chart) error {
+	if cv.IsDataFormat() {
+		table, err := cv.CreateTable()
 		if err != nil {
-			if rc.Logger() != nil {
-				rc.Logger().Errorf("render error: %s", err.Error())
-			}
+			return err
 		}
-	} else if util.String.CaseInsensitiveEquals(cv.Format, "svg") {
-		rc.Response.Header().Set("Content-Type", "image/svg+xml")
-		err := graph.Render(chart.SVG, rc.Response)
+		return cc.renderTable(rc, w, cv.Format, table)
+	}
+
+	if cv.IsVegaLiteFormat() {
+		spec, err := cv.CreateVegaLite()
 		if err != nil {
-			if rc.Logger() != nil {
-				rc.Logger().Errorf("render error: %s", err.Error())
-			}
+			return err
 		}
+		rc.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
+		return json.NewEncoder(w).Encode(spec)
+	}
+
+	graph, err := cv.CreateImage()
+	if err != nil {
+		return err
+	}
+	return cc.render(rc, w, cv.Format, graph)
+}
+
+// cached writes a rendered chart with the headers to cache it by, or that it's not modified if the
+// client already has it.
+func (cc Charts) cached(rc *web.Ctx, entry *core.RenderCacheEntry) web.Result {
+	header := rc.Response.Header()
+	header.Set("ETag", entry.ETag)
+	if !entry.LastModified.IsZero() {
+		header.Set("Last-Modified", entry.LastModified.UTC().Format(http.TimeFormat))
+	}
+	header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", entry.MaxAge(time.Now())))
+	if rc.Request.Method == http.MethodGet && core.IsNotModified(rc, entry.ETag, entry.LastModified) {
+		return &web.RawResult{StatusCode: http.StatusNotModified}
 	}
+	return rc.RawWithContentType(entry.ContentType, entry.Body)
+}
 
+func (cc Charts) getSparklineAction(rc *web.Ctx) web.Result {
+	sv := &viewmodel.Sparkline{}
+	err := sv.Parse(rc)
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = sv.ParsePeriod()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = sv.Validate()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = sv.FetchTickers()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = sv.FetchPriceData()
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+
+	graph, err := sv.CreateImage()
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+
+	err = cc.render(rc, rc.Response, sv.Format, graph)
+	if err != nil {
+		if rc.Logger() != nil {
+			rc.Logger().Errorf("render error: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func (cc Charts) getGridAction(rc *web.Ctx) web.Result {
+	gv := &viewmodel.Grid{}
+	err := gv.Parse(rc)
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = gv.ParsePeriod()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = gv.Validate()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = gv.FetchTickers()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = gv.FetchPriceData()
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+
+	graph, err := gv.CreateImage()
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+
+	err = cc.render(rc, rc.Response, gv.Format, graph)
+	if err != nil {
+		if rc.Logger() != nil {
+			rc.Logger().Errorf("render error: %s", err.Error())
+		}
+	}
 	return nil
 }
 
+// render writes the image to `w` in the requested format, setting the content type of the response.
+func (cc Charts) render(rc *web.Ctx, w io.Writer, format string, graph core.Renderable) error {
+	if util.String.CaseInsensitiveEquals(format, "png") {
+		rc.Response.Header().Set("Content-Type", "image/png")
+		return graph.Render(chart.PNG, w)
+	} else if util.String.CaseInsensitiveEquals(format, "svg") {
+		rc.Response.Header().Set("Content-Type", "image/svg+xml")
+		return graph.Render(core.SVG, w)
+	} else if util.String.CaseInsensitiveEquals(format, "txt") {
+		rc.Response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		return graph.Render(core.Text, w)
+	} else if util.String.CaseInsensitiveEquals(format, "ansi") {
+		rc.Response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		return graph.Render(core.ANSI, w)
+	} else if util.String.CaseInsensitiveEquals(format, "html") {
+		rc.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		return graph.Render(core.SVG, w)
+	} else if util.String.CaseInsensitiveEquals(format, "gif") {
+		rc.Response.Header().Set("Content-Type", "image/gif")
+		return graph.Render(chart.PNG, w)
+	} else if util.String.CaseInsensitiveEquals(format, "pdf") {
+		rc.Response.Header().Set("Content-Type", "application/pdf")
+		return graph.Render(core.PDF, w)
+	}
+	return fmt.Errorf("unsupported format: %s", format)
+}
+
+// renderTable writes the chart's series to `w` in the requested format, setting the content type of the response.
+func (cc Charts) renderTable(rc *web.Ctx, w io.Writer, format string, table core.SeriesTable) error {
+	if util.String.CaseInsensitiveEquals(format, "csv") {
+		rc.Response.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		return table.WriteCSV(w)
+	} else if util.String.CaseInsensitiveEquals(format, "json") {
+		rc.Response.Header().Set("Content-Type", "application/json; charset=utf-8")
+		return table.WriteJSON(w)
+	}
+	return fmt.Errorf("unsupported format: %s", format)
+}
+
 // Register registers the controller.
 func (cc Charts) Register(app *web.App) {
 	app.GET("/stock/chart/:ticker", cc.getChartAction)
 	app.GET("/stock/chart/:ticker/:timeframe", cc.getChartAction)
+	app.POST("/stock/chart", cc.postChartAction)
+	app.GET("/api/v1/chart.schema", cc.getChartSchemaAction)
+	app.GET("/stock/sparkline/:ticker", cc.getSparklineAction)
+	app.GET("/stock/sparkline/:ticker/:timeframe", cc.getSparklineAction)
+	app.GET("/stock/grid", cc.getGridAction)
+	app.GET("/stock/grid/:timeframe", cc.getGridAction)
 }
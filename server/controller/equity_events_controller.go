@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"github.com/blendlabs/go-web"
+	"github.com/blendlabs/spiffy"
+	"github.com/wcharczuk/chart-service/server/core"
+	"github.com/wcharczuk/chart-service/server/model"
+)
+
+// EquityEvents is the equity events controller.
+type EquityEvents struct{}
+
+// Register registers the controller.
+func (ee EquityEvents) Register(app *web.App) {
+	app.GET("/api/v1/equity.events/:ticker", ee.getEventsAction)
+	app.POST("/api/v1/equity_event", ee.createHandler, core.AuthRequired, web.APIProviderAsDefault)
+	app.GET("/api/v1/equity_event/:id", ee.getHandler)
+	app.PUT("/api/v1/equity_event/:id", ee.updateHandler, core.AuthRequired, web.APIProviderAsDefault)
+	app.DELETE("/api/v1/equity_event/:id", ee.deleteHandler, core.AuthRequired, web.APIProviderAsDefault)
+}
+
+func (ee EquityEvents) getEventsAction(rc *web.Ctx) web.Result {
+	ticker, err := rc.RouteParam("ticker")
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+
+	events, err := model.GetEquityEvents(ticker)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().Result(events)
+}
+
+func (ee EquityEvents) getHandler(rc *web.Ctx) web.Result {
+	id, err := rc.RouteParamInt("id")
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	var event model.EquityEvent
+	err = spiffy.Default().GetByID(&event, id)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	if event.IsZero() {
+		return rc.API().NotFound()
+	}
+	return rc.API().Result(event)
+}
+
+func (ee EquityEvents) createHandler(rc *web.Ctx) web.Result {
+	var event model.EquityEvent
+	err := rc.PostBodyAsJSON(&event)
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = event.Validate()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = spiffy.Default().Create(&event)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().Result(event)
+}
+
+func (ee EquityEvents) updateHandler(rc *web.Ctx) web.Result {
+	id, err := rc.RouteParamInt("id")
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	var reference model.EquityEvent
+	err = spiffy.Default().GetByID(&reference, id)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	if reference.IsZero() {
+		return rc.API().NotFound()
+	}
+
+	var event model.EquityEvent
+	err = rc.PostBodyAsJSON(&event)
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+
+	event.ID = reference.ID
+	err = event.Validate()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+
+	err = spiffy.Default().Update(&event)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().Result(event)
+}
+
+func (ee EquityEvents) deleteHandler(rc *web.Ctx) web.Result {
+	id, err := rc.RouteParamInt("id")
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	var event model.EquityEvent
+	err = spiffy.Default().GetByID(&event, id)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	if event.IsZero() {
+		return rc.API().NotFound()
+	}
+
+	err = spiffy.Default().Delete(event)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().OK()
+}
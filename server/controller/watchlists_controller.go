@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"github.com/blendlabs/go-web"
+	"github.com/blendlabs/spiffy"
+	"github.com/wcharczuk/chart-service/server/core"
+	"github.com/wcharczuk/chart-service/server/model"
+)
+
+// Watchlists is the watchlists controller.
+type Watchlists struct{}
+
+// Register registers the controller.
+func (wl Watchlists) Register(app *web.App) {
+	app.GET("/api/v1/watchlists", wl.getAllHandler)
+	app.POST("/api/v1/watchlist", wl.createHandler, core.AuthRequired, web.APIProviderAsDefault)
+	app.GET("/api/v1/watchlist/:id", wl.getHandler)
+	app.PUT("/api/v1/watchlist/:id", wl.updateHandler, core.AuthRequired, web.APIProviderAsDefault)
+	app.DELETE("/api/v1/watchlist/:id", wl.deleteHandler, core.AuthRequired, web.APIProviderAsDefault)
+}
+
+func (wl Watchlists) getAllHandler(rc *web.Ctx) web.Result {
+	var all []model.Watchlist
+	err := spiffy.Default().GetAll(&all)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().Result(all)
+}
+
+func (wl Watchlists) getHandler(rc *web.Ctx) web.Result {
+	id, err := rc.RouteParamInt("id")
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	var watchlist model.Watchlist
+	err = spiffy.Default().GetByID(&watchlist, id)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	if watchlist.IsZero() {
+		return rc.API().NotFound()
+	}
+	return rc.API().Result(watchlist)
+}
+
+func (wl Watchlists) createHandler(rc *web.Ctx) web.Result {
+	var watchlist model.Watchlist
+	err := rc.PostBodyAsJSON(&watchlist)
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = watchlist.Validate()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = spiffy.Default().Create(&watchlist)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().Result(watchlist)
+}
+
+func (wl Watchlists) updateHandler(rc *web.Ctx) web.Result {
+	id, err := rc.RouteParamInt("id")
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	var reference model.Watchlist
+	err = spiffy.Default().GetByID(&reference, id)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	if reference.IsZero() {
+		return rc.API().NotFound()
+	}
+
+	var watchlist model.Watchlist
+	err = rc.PostBodyAsJSON(&watchlist)
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	err = watchlist.Validate()
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+
+	watchlist.ID = reference.ID
+
+	err = spiffy.Default().Update(&watchlist)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().Result(watchlist)
+}
+
+func (wl Watchlists) deleteHandler(rc *web.Ctx) web.Result {
+	id, err := rc.RouteParamInt("id")
+	if err != nil {
+		return rc.API().BadRequest(err.Error())
+	}
+	var watchlist model.Watchlist
+	err = spiffy.Default().GetByID(&watchlist, id)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	if watchlist.IsZero() {
+		return rc.API().NotFound()
+	}
+
+	err = spiffy.Default().Delete(watchlist)
+	if err != nil {
+		return rc.API().InternalError(err)
+	}
+	return rc.API().OK()
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"sort"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+const (
+	// DefaultAnimationDelay is how long each frame of an animation shows, in hundredths of a second.
+	DefaultAnimationDelay = 10
+	// DefaultAnimationLastDelay is how long the last frame holds before the animation loops.
+	DefaultAnimationLastDelay = 300
+
+	// gifPaletteSize is the most colors a gif frame can have.
+	gifPaletteSize = 256
+)
+
+// Animation is a renderable of frames encoded as a looping animated gif. Each frame is rendered with the
+// renderer provider, which should draw png images (i.e. `chart.PNG`), then reduced to a palette of the most
+// common colors of the last frame.
+type Animation struct {
+	Frames []Renderable
+	// Delay and LastDelay are how long each frame, and the last frame, show in hundredths of a second.
+	Delay     int
+	LastDelay int
+}
+
+// Render implements Renderable.
+func (a Animation) Render(rp chart.RendererProvider, w io.Writer) error {
+	frames := make([]image.Image, len(a.Frames))
+	for index, frame := range a.Frames {
+		buffer := bytes.NewBuffer(nil)
+		if err := frame.Render(rp, buffer); err != nil {
+			return err
+		}
+		decoded, err := png.Decode(buffer)
+		if err != nil {
+			return err
+		}
+		frames[index] = decoded
+	}
+
+	if len(frames) == 0 {
+		return errors.New("an animation needs at least one frame")
+	}
+	animation := &gif.GIF{}
+	quantizer := newGIFQuantizer(frames[len(frames)-1])
+	for index, frame := range frames {
+		delay := a.getDelay()
+		if index == len(frames)-1 {
+			delay = a.getLastDelay()
+		}
+		animation.Image = append(animation.Image, quantizer.quantize(frame))
+		animation.Delay = append(animation.Delay, delay)
+	}
+	return gif.EncodeAll(w, animation)
+}
+
+func (a Animation) getDelay() int {
+	if a.Delay > 0 {
+		return a.Delay
+	}
+	return DefaultAnimationDelay
+}
+
+func (a Animation) getLastDelay() int {
+	if a.LastDelay > 0 {
+		return a.LastDelay
+	}
+	return DefaultAnimationLastDelay
+}
+
+// gifQuantizer maps colors to a fixed palette, remembering the palette index of each color it has seen.
+type gifQuantizer struct {
+	palette color.Palette
+	indexes map[color.RGBA]uint8
+}
+
+// newGIFQuantizer returns a quantizer with a palette of the most common colors of an image; charts are mostly
+// flat colors, so the rest (the anti-aliased edges) map closely enough to their nearest color.
+func newGIFQuantizer(img image.Image) *gifQuantizer {
+	counts := map[color.RGBA]int{}
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			counts[color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)]++
+		}
+	}
+	colors := make(gifColorCounts, 0, len(counts))
+	for c, count := range counts {
+		colors = append(colors, gifColorCount{color: c, count: count})
+	}
+	sort.Sort(colors)
+
+	quantizer := &gifQuantizer{indexes: map[color.RGBA]uint8{}}
+	for index := 0; index < len(colors) && index < gifPaletteSize; index++ {
+		quantizer.palette = append(quantizer.palette, colors[index].color)
+		quantizer.indexes[colors[index].color] = uint8(index)
+	}
+	return quantizer
+}
+
+func (gq *gifQuantizer) quantize(img image.Image) *image.Paletted {
+	bounds := img.Bounds()
+	paletted := image.NewPaletted(bounds, gq.palette)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			index, ok := gq.indexes[c]
+			if !ok {
+				index = uint8(gq.palette.Index(c))
+				gq.indexes[c] = index
+			}
+			paletted.SetColorIndex(x, y, index)
+		}
+	}
+	return paletted
+}
+
+// gifColorCount is how many pixels of an image are a color.
+type gifColorCount struct {
+	color color.RGBA
+	count int
+}
+
+// gifColorCounts sorts colors by how common they are, most common first; ties are broken by the color
+// so the palette is the same every time.
+type gifColorCounts []gifColorCount
+
+func (gcc gifColorCounts) Len() int {
+	return len(gcc)
+}
+
+func (gcc gifColorCounts) Swap(i, j int) {
+	gcc[i], gcc[j] = gcc[j], gcc[i]
+}
+
+func (gcc gifColorCounts) Less(i, j int) bool {
+	if gcc[i].count != gcc[j].count {
+		return gcc[i].count > gcc[j].count
+	}
+	a, b := gcc[i].color, gcc[j].color
+	return uint32(a.R)<<24|uint32(a.G)<<16|uint32(a.B)<<8|uint32(a.A) < uint32(b.R)<<24|uint32(b.G)<<16|uint32(b.B)<<8|uint32(b.A)
+}
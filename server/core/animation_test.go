@@ -0,0 +1,39 @@
+package core
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestAnimationRender(t *testing.T) {
+	assert := assert.New(t)
+
+	frame := func(count int) Renderable {
+		return chart.Chart{
+			Width:  100,
+			Height: 50,
+			XAxis:  chart.XAxis{Range: &chart.ContinuousRange{Min: 0, Max: 3}},
+			Series: []chart.Series{
+				chart.ContinuousSeries{XValues: []float64{0, 1, 2, 3}[:count], YValues: []float64{1, 3, 2, 4}[:count]},
+			},
+		}
+	}
+	animation := Animation{Frames: []Renderable{frame(2), frame(3), frame(4)}, Delay: 5}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(animation.Render(chart.PNG, buffer))
+	decoded, err := gif.DecodeAll(buffer)
+	assert.Nil(err)
+	assert.Len(decoded.Image, 3)
+	assert.Equal([]int{5, 5, DefaultAnimationLastDelay}, decoded.Delay)
+	assert.Zero(decoded.LoopCount, "the animation loops forever")
+	assert.Equal(color.RGBA{R: 255, G: 255, B: 255, A: 255}, decoded.Image[0].Palette[0], "the background is the most common color")
+	assert.True(len(decoded.Image[0].Palette) <= gifPaletteSize)
+
+	assert.NotNil(Animation{}.Render(chart.PNG, bytes.NewBuffer(nil)))
+}
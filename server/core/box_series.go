@@ -0,0 +1,185 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// MaxBoxLevels is the most box levels a renko or point & figure chart can span; a column never has more marks.
+const MaxBoxLevels = 2000
+
+// BoxValue is a renko brick or a point & figure column spanning Low to High.
+type BoxValue struct {
+	Low  float64
+	High float64
+	IsUp bool
+}
+
+// RenkoSeries draws renko bricks, one per index along an `IndexRange` x-axis.
+// Rising bricks are hollow (outlined in the up color) and falling bricks are filled with the down color.
+type RenkoSeries struct {
+	Name      string
+	Style     chart.Style
+	YAxis     chart.YAxisType
+	BoxValues []BoxValue
+
+	UpColor   drawing.Color
+	DownColor drawing.Color
+}
+
+// GetName implements chart.Series.
+func (rs *RenkoSeries) GetName() string {
+	return rs.Name
+}
+
+// GetStyle implements chart.Series.
+func (rs *RenkoSeries) GetStyle() chart.Style {
+	return rs.Style
+}
+
+// GetYAxis implements chart.Series.
+func (rs *RenkoSeries) GetYAxis() chart.YAxisType {
+	return rs.YAxis
+}
+
+// Len implements chart.BoundedValuesProvider.
+func (rs *RenkoSeries) Len() int {
+	return len(rs.BoxValues)
+}
+
+// GetBoundedValues implements chart.BoundedValuesProvider.
+func (rs *RenkoSeries) GetBoundedValues(index int) (x, y0, y1 float64) {
+	return float64(index), rs.BoxValues[index].Low, rs.BoxValues[index].High
+}
+
+// Render implements chart.Series.
+func (rs *RenkoSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	style := rs.Style.InheritFrom(defaults)
+	cb := canvasBox.Bottom
+	for index, bv := range rs.BoxValues {
+		color := getUpDownColor(bv.IsUp, rs.UpColor, rs.DownColor)
+		fill := color
+		if bv.IsUp {
+			fill = chart.ColorTransparent
+		}
+
+		left, right := BoxColumn(canvasBox, xrange, index)
+		chart.Draw.Box(r, chart.Box{
+			Top:    cb - yrange.Translate(bv.High),
+			Left:   left,
+			Right:  right,
+			Bottom: cb - yrange.Translate(bv.Low),
+		}, chart.Style{
+			StrokeColor: color,
+			FillColor:   fill,
+			StrokeWidth: style.GetStrokeWidth(),
+		})
+	}
+}
+
+// Validate implements chart.Series.
+func (rs *RenkoSeries) Validate() error {
+	if rs.BoxValues == nil {
+		return fmt.Errorf("renko series requires `BoxValues` to be set")
+	}
+	return nil
+}
+
+// PointAndFigureSeries draws point & figure columns, one per index along an `IndexRange` x-axis.
+// Each column is a stack of marks BoxSize apart from Low to High (inclusive); rising columns are drawn
+// as Xs in the up color and falling columns as Os in the down color.
+type PointAndFigureSeries struct {
+	Name      string
+	Style     chart.Style
+	YAxis     chart.YAxisType
+	BoxSize   float64
+	BoxValues []BoxValue
+
+	UpColor   drawing.Color
+	DownColor drawing.Color
+}
+
+// GetName implements chart.Series.
+func (pfs *PointAndFigureSeries) GetName() string {
+	return pfs.Name
+}
+
+// GetStyle implements chart.Series.
+func (pfs *PointAndFigureSeries) GetStyle() chart.Style {
+	return pfs.Style
+}
+
+// GetYAxis implements chart.Series.
+func (pfs *PointAndFigureSeries) GetYAxis() chart.YAxisType {
+	return pfs.YAxis
+}
+
+// Len implements chart.BoundedValuesProvider.
+func (pfs *PointAndFigureSeries) Len() int {
+	return len(pfs.BoxValues)
+}
+
+// GetBoundedValues implements chart.BoundedValuesProvider.
+// The bounds include the half box above and below the outermost marks.
+func (pfs *PointAndFigureSeries) GetBoundedValues(index int) (x, y0, y1 float64) {
+	bv := pfs.BoxValues[index]
+	return float64(index), bv.Low - pfs.BoxSize/2, bv.High + pfs.BoxSize/2
+}
+
+// Render implements chart.Series.
+func (pfs *PointAndFigureSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	style := pfs.Style.InheritFrom(defaults)
+	cb := canvasBox.Bottom
+	for index, bv := range pfs.BoxValues {
+		chart.Style{
+			StrokeColor: getUpDownColor(bv.IsUp, pfs.UpColor, pfs.DownColor),
+			StrokeWidth: style.GetStrokeWidth(),
+		}.WriteToRenderer(r)
+
+		left, right := BoxColumn(canvasBox, xrange, index)
+		x := left + ((right - left) >> 1)
+		marks := int(math.Min(math.Floor((bv.High-bv.Low)/pfs.BoxSize+0.5)+1, MaxBoxLevels))
+		for mark := 0; mark < marks; mark++ {
+			level := bv.Low + float64(mark)*pfs.BoxSize
+			top, bottom := cb-yrange.Translate(level+pfs.BoxSize/2), cb-yrange.Translate(level-pfs.BoxSize/2)
+			y := top + ((bottom - top) >> 1)
+			half := util.Math.MaxInt(util.Math.MinInt(right-left, bottom-top)>>1, 1)
+
+			if bv.IsUp {
+				r.MoveTo(x-half, y-half)
+				r.LineTo(x+half, y+half)
+				r.Stroke()
+				r.MoveTo(x-half, y+half)
+				r.LineTo(x+half, y-half)
+				r.Stroke()
+			} else {
+				r.Circle(float64(half), x, y)
+				r.Stroke()
+			}
+		}
+	}
+}
+
+// Validate implements chart.Series.
+func (pfs *PointAndFigureSeries) Validate() error {
+	if pfs.BoxValues == nil {
+		return fmt.Errorf("point & figure series requires `BoxValues` to be set")
+	}
+	if pfs.BoxSize <= 0 {
+		return fmt.Errorf("point & figure series requires a positive `BoxSize`")
+	}
+	return nil
+}
+
+// BoxColumn returns the canvas left and right x coordinates of the item at an index along an `IndexRange`,
+// leaving a gap between adjacent items.
+func BoxColumn(canvasBox chart.Box, xrange chart.Range, index int) (left, right int) {
+	left = canvasBox.Left + xrange.Translate(float64(index)-0.5)
+	right = canvasBox.Left + xrange.Translate(float64(index)+0.5)
+	gap := util.Math.MaxInt((right-left)/10, 1)
+	return left + gap, util.Math.MaxInt(right-gap, left+gap)
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestBoxColumn(t *testing.T) {
+	assert := assert.New(t)
+
+	xrange := NewIndexRange([]string{"a", "b", "c", "d"})
+	xrange.SetDomain(400)
+	canvasBox := chart.Box{Left: 10, Right: 410}
+
+	left, right := BoxColumn(canvasBox, xrange, 0)
+	assert.Equal(20, left)
+	assert.Equal(100, right)
+	left, right = BoxColumn(canvasBox, xrange, 3)
+	assert.Equal(320, left)
+	assert.Equal(400, right)
+}
+
+func TestIndexRangeGetTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	font, err := chart.GetDefaultFont()
+	assert.Nil(err)
+	r, err := chart.PNG(1024, 1024)
+	assert.Nil(err)
+	defaults := chart.Style{Font: font, FontSize: chart.DefaultAxisFontSize}
+
+	labels := make([]string, 100)
+	for index := range labels {
+		labels[index] = "2017-01-02"
+	}
+	xrange := NewIndexRange(labels)
+	assert.Equal(-0.5, xrange.GetMin())
+	assert.Equal(99.5, xrange.GetMax())
+
+	xrange.SetDomain(800)
+	ticks := xrange.GetTicks(r, defaults, nil)
+	assert.True(len(ticks) > 1 && len(ticks) < len(labels))
+	assert.Equal(0.0, ticks[0].Value)
+	assert.Equal("2017-01-02", ticks[0].Label)
+	step := ticks[1].Value - ticks[0].Value
+	assert.True(step > 1)
+
+	xrange.SetDomain(1 << 20)
+	assert.Len(xrange.GetTicks(r, defaults, nil), len(labels))
+}
+
+func TestRenkoSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	series := &RenkoSeries{
+		Style: chart.StyleShow(),
+		BoxValues: []BoxValue{
+			{Low: 10, High: 11, IsUp: true},
+			{Low: 11, High: 12, IsUp: true},
+			{Low: 10, High: 11},
+		},
+	}
+	assert.Nil(series.Validate())
+	x, low, high := series.GetBoundedValues(2)
+	assert.Equal(2.0, x)
+	assert.Equal(10.0, low)
+	assert.Equal(11.0, high)
+
+	graph := chart.Chart{
+		Width:  200,
+		Height: 200,
+		XAxis:  chart.XAxis{Range: NewIndexRange([]string{"a", "b", "c"})},
+		Series: []chart.Series{series},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	// rising bricks are hollow and falling bricks are filled.
+	assert.Contains("fill:rgba(1,1,1,0.0)", buffer.String())
+	assert.Contains("fill:rgba(217,0,116,1.0)", buffer.String())
+}
+
+func TestPointAndFigureSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	series := &PointAndFigureSeries{
+		Style:   chart.StyleShow(),
+		BoxSize: 1,
+		BoxValues: []BoxValue{
+			{Low: 10, High: 13, IsUp: true},
+			{Low: 11, High: 12},
+		},
+	}
+	assert.Nil(series.Validate())
+	_, low, high := series.GetBoundedValues(0)
+	assert.Equal(9.5, low)
+	assert.Equal(13.5, high)
+
+	graph := chart.Chart{
+		Width:  200,
+		Height: 200,
+		XAxis:  chart.XAxis{Range: NewIndexRange([]string{"a", "b"})},
+		Series: []chart.Series{series},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	// one O per mark in the falling column.
+	assert.Equal(2, strings.Count(buffer.String(), "<circle"))
+
+	series.BoxSize = 0
+	assert.NotNil(series.Validate())
+}
+
+func TestPointAndFigureSeriesRenderMaxMarks(t *testing.T) {
+	assert := assert.New(t)
+
+	series := &PointAndFigureSeries{
+		Style:     chart.StyleShow(),
+		BoxSize:   0.0001,
+		BoxValues: []BoxValue{{Low: 0, High: 500}},
+	}
+	graph := chart.Chart{
+		Width:  200,
+		Height: 200,
+		XAxis:  chart.XAxis{Range: NewIndexRange([]string{"a"})},
+		Series: []chart.Series{series},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	assert.Equal(MaxBoxLevels, strings.Count(buffer.String(), "<circle"), "a column has at most `MaxBoxLevels` marks")
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+var (
+	// DefaultCandleUpColor is the default color for candles that close above their open.
+	DefaultCandleUpColor = chart.ColorAlternateGreen
+	// DefaultCandleDownColor is the default color for candles that close below their open.
+	DefaultCandleDownColor = chart.ColorRed
+)
+
+// HollowCandlestickSeries draws candlesticks where candles that close above their open are hollow
+// (outlined in the up color) and candles that close below their open are filled with the down color.
+type HollowCandlestickSeries struct {
+	Name         string
+	Style        chart.Style
+	YAxis        chart.YAxisType
+	CandleValues []chart.CandleValue
+
+	UpColor   drawing.Color
+	DownColor drawing.Color
+}
+
+// GetName implements chart.Series.
+func (hcs *HollowCandlestickSeries) GetName() string {
+	return hcs.Name
+}
+
+// GetStyle implements chart.Series.
+func (hcs *HollowCandlestickSeries) GetStyle() chart.Style {
+	return hcs.Style
+}
+
+// GetYAxis implements chart.Series.
+func (hcs *HollowCandlestickSeries) GetYAxis() chart.YAxisType {
+	return hcs.YAxis
+}
+
+// Len implements chart.BoundedValuesProvider.
+func (hcs *HollowCandlestickSeries) Len() int {
+	return len(hcs.CandleValues)
+}
+
+// GetBoundedValues implements chart.BoundedValuesProvider.
+func (hcs *HollowCandlestickSeries) GetBoundedValues(index int) (x, y0, y1 float64) {
+	value := hcs.CandleValues[index]
+	return util.Time.ToFloat64(value.Timestamp), value.Low, value.High
+}
+
+// GetUpColor returns the up color or a default.
+func (hcs *HollowCandlestickSeries) GetUpColor() drawing.Color {
+	if hcs.UpColor.IsZero() {
+		return DefaultCandleUpColor
+	}
+	return hcs.UpColor
+}
+
+// GetDownColor returns the down color or a default.
+func (hcs *HollowCandlestickSeries) GetDownColor() drawing.Color {
+	if hcs.DownColor.IsZero() {
+		return DefaultCandleDownColor
+	}
+	return hcs.DownColor
+}
+
+// Render implements chart.Series.
+func (hcs *HollowCandlestickSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	style := hcs.Style.InheritFrom(defaults)
+	centers := CandleCenters(canvasBox, xrange, hcs.CandleValues)
+	halfWidth := CandleWidth(centers) >> 1
+
+	cb := canvasBox.Bottom
+	for index, cv := range hcs.CandleValues {
+		x := centers[index]
+		isUp := cv.Close >= cv.Open
+
+		color := hcs.GetDownColor()
+		fill := color
+		if isUp {
+			color = hcs.GetUpColor()
+			fill = chart.ColorTransparent
+		}
+		candleStyle := chart.Style{
+			StrokeColor: color,
+			FillColor:   fill,
+			StrokeWidth: style.GetStrokeWidth(),
+		}
+
+		yopen, yclose := cb-yrange.Translate(cv.Open), cb-yrange.Translate(cv.Close)
+		yhigh, ylow := cb-yrange.Translate(cv.High), cb-yrange.Translate(cv.Low)
+		bodyTop, bodyBottom := util.Math.MinInt(yopen, yclose), util.Math.MaxInt(yopen, yclose)
+
+		// draw the wicks first, outside the body so hollow candles stay hollow.
+		candleStyle.GetStrokeOptions().WriteToRenderer(r)
+		r.MoveTo(x, yhigh)
+		r.LineTo(x, bodyTop)
+		r.Stroke()
+		r.MoveTo(x, bodyBottom)
+		r.LineTo(x, ylow)
+		r.Stroke()
+
+		chart.Draw.Box(r, chart.Box{
+			Top:    bodyTop,
+			Left:   x - halfWidth,
+			Right:  x + halfWidth,
+			Bottom: bodyBottom,
+		}, candleStyle)
+	}
+}
+
+// Validate implements chart.Series.
+func (hcs *HollowCandlestickSeries) Validate() error {
+	if hcs.CandleValues == nil {
+		return fmt.Errorf("hollow candlestick series requires `CandleValues` to be set")
+	}
+	return nil
+}
+
+// CandleCenters returns the canvas x coordinate for the center of each candle, which is the
+// middle of the NYSE trading session on the candle's date.
+func CandleCenters(canvasBox chart.Box, xrange chart.Range, candles []chart.CandleValue) []int {
+	centers := make([]int, len(candles))
+	for index, cv := range candles {
+		x0 := xrange.Translate(util.Time.ToFloat64(util.Date.On(util.NYSEOpen(), cv.Timestamp)))
+		x1 := xrange.Translate(util.Time.ToFloat64(util.Date.On(util.NYSEClose(), cv.Timestamp)))
+		centers[index] = canvasBox.Left + x0 + ((x1 - x0) >> 1)
+	}
+	return centers
+}
+
+// CandleWidth returns a bar width (in pixels) derived from the tightest spacing between candle centers,
+// leaving a gap between adjacent candles.
+func CandleWidth(centers []int) int {
+	if len(centers) < 2 {
+		return 6
+	}
+	minSpacing := math.MaxInt32
+	for index := 1; index < len(centers); index++ {
+		if spacing := util.Math.AbsInt(centers[index] - centers[index-1]); spacing > 0 && spacing < minSpacing {
+			minSpacing = spacing
+		}
+	}
+	if minSpacing == math.MaxInt32 {
+		return 1
+	}
+	return util.Math.MaxInt(int(float64(minSpacing)*0.7), 1)
+}
+
+// getUpDownColor returns the up or down color, or its default.
+func getUpDownColor(isUp bool, upColor, downColor drawing.Color) drawing.Color {
+	if isUp {
+		if upColor.IsZero() {
+			return DefaultCandleUpColor
+		}
+		return upColor
+	}
+	if downColor.IsZero() {
+		return DefaultCandleDownColor
+	}
+	return downColor
+}
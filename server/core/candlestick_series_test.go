@@ -0,0 +1,45 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestCandleWidth(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(6, CandleWidth(nil))
+	assert.Equal(6, CandleWidth([]int{10}))
+	assert.Equal(7, CandleWidth([]int{10, 20, 40}))
+	assert.Equal(1, CandleWidth([]int{10, 10}))
+	assert.Equal(1, CandleWidth([]int{10, 11}))
+}
+
+func TestHollowCandlestickSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 03, 01, 12, 0, 0, 0, time.UTC)
+	series := &HollowCandlestickSeries{
+		Style: chart.StyleShow(),
+		CandleValues: []chart.CandleValue{
+			{Timestamp: start, Open: 10, High: 12, Low: 9, Close: 11},
+			{Timestamp: start.AddDate(0, 0, 1), Open: 11, High: 11.5, Low: 8, Close: 9},
+		},
+	}
+	assert.Nil(series.Validate())
+	assert.Equal(2, series.Len())
+	_, low, high := series.GetBoundedValues(1)
+	assert.Equal(8.0, low)
+	assert.Equal(11.5, high)
+
+	graph := chart.Chart{Width: 200, Height: 200, Series: []chart.Series{series}}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	// the up candle body is hollow and the down candle body is filled.
+	assert.Contains("fill:rgba(1,1,1,0.0)", buffer.String())
+	assert.Contains("fill:rgba(217,0,116,1.0)", buffer.String())
+}
@@ -58,6 +58,11 @@ func (c *config) AuthKey() string {
 	return c.authKey
 }
 
+// ThemesPath is the path to a json file of custom chart themes, if any.
+func (c *config) ThemesPath() string {
+	return env.Env().String("THEMES_PATH")
+}
+
 func (c *config) IsProduction() bool {
 	return util.String.CaseInsensitiveEquals(env.Env().String("ENV", DefaultEnv), "prod")
 }
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wcharczuk/go-chart"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// Event is a dated marker on a time series chart.
+type Event struct {
+	Timestamp time.Time
+	Label     string
+	// Style overrides the series style for this event (i.e. to color events by kind).
+	Style chart.Style
+}
+
+// EventSeries draws each event as a dashed vertical line across the canvas with a flag at the top
+// holding its label. Flags that would overlap are stacked into rows beneath each other.
+type EventSeries struct {
+	Name   string
+	Style  chart.Style
+	YAxis  chart.YAxisType
+	Events []Event
+}
+
+// GetName implements chart.Series.
+func (es EventSeries) GetName() string {
+	return es.Name
+}
+
+// GetStyle implements chart.Series.
+func (es EventSeries) GetStyle() chart.Style {
+	return es.Style
+}
+
+// GetYAxis implements chart.Series.
+func (es EventSeries) GetYAxis() chart.YAxisType {
+	return es.YAxis
+}
+
+// Render implements chart.Series.
+func (es EventSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	seriesStyle := es.Style.InheritFrom(defaults)
+	if seriesStyle.FillColor.IsZero() {
+		seriesStyle.FillColor = chart.DefaultAnnotationFillColor
+	}
+	if seriesStyle.FontColor.IsZero() {
+		seriesStyle.FontColor = chart.DefaultTextColor
+	}
+
+	// rowRights are the right edges of the last flag in each row.
+	var rowRights []int
+	for _, event := range es.Events {
+		x := canvasBox.Left + xrange.Translate(util.Time.ToFloat64(event.Timestamp))
+		if x < canvasBox.Left || x > canvasBox.Right {
+			continue
+		}
+		style := event.Style.InheritFrom(seriesStyle)
+
+		chart.Style{
+			StrokeColor:     style.StrokeColor,
+			StrokeWidth:     style.GetStrokeWidth(),
+			StrokeDashArray: []float64{2.0, 2.0},
+		}.WriteToRenderer(r)
+		r.MoveTo(x, canvasBox.Top)
+		r.LineTo(x, canvasBox.Bottom)
+		r.Stroke()
+
+		if len(event.Label) == 0 {
+			continue
+		}
+		flag := chart.Draw.MeasureAnnotation(r, canvasBox, style, x, 0, event.Label)
+		row := 0
+		for row < len(rowRights) && x <= rowRights[row] {
+			row++
+		}
+		if row == len(rowRights) {
+			rowRights = append(rowRights, 0)
+		}
+		rowRights[row] = flag.Right
+
+		y := canvasBox.Top + (flag.Height() >> 1) + row*(flag.Height()+1)
+		chart.Draw.Annotation(r, canvasBox, style, x, y, event.Label)
+	}
+}
+
+// Validate implements chart.Series.
+func (es EventSeries) Validate() error {
+	if es.Events == nil {
+		return fmt.Errorf("event series requires `Events` to be set")
+	}
+	return nil
+}
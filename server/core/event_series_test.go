@@ -0,0 +1,44 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestEventSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 03, 01, 12, 0, 0, 0, time.UTC)
+	prices := chart.TimeSeries{
+		Style:   chart.StyleShow(),
+		XValues: []time.Time{start, start.AddDate(0, 0, 10)},
+		YValues: []float64{10, 11},
+	}
+	events := EventSeries{
+		Style: chart.StyleShow(),
+		Events: []Event{
+			{Timestamp: start.AddDate(0, 0, 2), Label: "Earnings", Style: chart.Style{StrokeColor: drawing.ColorBlue}},
+			{Timestamp: start.AddDate(0, 0, 2), Label: "Div. 0.57"},
+			{Timestamp: start.AddDate(0, 0, 30), Label: "Out of range"},
+		},
+	}
+	assert.Nil(events.Validate())
+
+	graph := chart.Chart{Width: 400, Height: 200, Series: []chart.Series{prices, events}}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	svg := buffer.String()
+	assert.Contains(">Earnings</text>", svg)
+	assert.Contains(">Div. 0.57</text>", svg)
+	assert.False(strings.Contains(svg, "Out of range"), "events outside the x range are skipped")
+	assert.Contains("stroke:rgba(0,0,255,1.0)", svg)
+	assert.Contains("stroke-dasharray", svg)
+
+	assert.NotNil(EventSeries{}.Validate())
+}
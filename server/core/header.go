@@ -0,0 +1,85 @@
+package core
+
+import (
+	"io"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+const (
+	// DefaultHeaderHeight is the default height of a chart header.
+	DefaultHeaderHeight = 44
+	// DefaultHeaderPadding is the gap (in pixels) between the edges of a header and its text.
+	DefaultHeaderPadding = 8
+	// DefaultHeaderTitleFontSize is the default size of the title and the value.
+	DefaultHeaderTitleFontSize = 14.0
+	// DefaultHeaderSubtitleFontSize is the default size of the subtitle and the change.
+	DefaultHeaderSubtitleFontSize = 9.0
+)
+
+// Header is a two line banner above a chart; the title and subtitle on the left, and the
+// value (i.e. the last price) and its change on the right.
+type Header struct {
+	Width    int
+	Height   int
+	Title    string
+	Subtitle string
+	Value    string
+	Change   string
+
+	// Style sets the font and font color of the text; the change uses ChangeColor if it's set.
+	Style       chart.Style
+	ChangeColor drawing.Color
+	// Background sets the fill behind the header, if set.
+	Background chart.Style
+}
+
+// Render implements Renderable.
+func (h Header) Render(rp chart.RendererProvider, w io.Writer) error {
+	r, err := rp(h.Width, h.Height)
+	if err != nil {
+		return err
+	}
+
+	if !h.Background.FillColor.IsZero() {
+		chart.Draw.Box(r, chart.Box{Right: h.Width, Bottom: h.Height}, h.Background)
+	}
+
+	style := h.Style
+	if style.Font == nil {
+		font, err := chart.GetDefaultFont()
+		if err != nil {
+			return err
+		}
+		style.Font = font
+	}
+	style.FontColor = style.GetFontColor(chart.DefaultTextColor)
+
+	changeStyle := style
+	if !h.ChangeColor.IsZero() {
+		changeStyle.FontColor = h.ChangeColor
+	}
+
+	baseline := h.Height >> 1
+	h.text(r, style, DefaultHeaderTitleFontSize, h.Title, baseline, false)
+	h.text(r, style, DefaultHeaderSubtitleFontSize, h.Subtitle, h.Height-DefaultHeaderPadding, false)
+	h.text(r, style, DefaultHeaderTitleFontSize, h.Value, baseline, true)
+	h.text(r, changeStyle, DefaultHeaderSubtitleFontSize, h.Change, h.Height-DefaultHeaderPadding, true)
+	return r.Save(w)
+}
+
+// text draws a line of text on a baseline, against the left or right padding.
+func (h Header) text(r chart.Renderer, style chart.Style, fontSize float64, body string, baseline int, alignRight bool) {
+	if len(body) == 0 {
+		return
+	}
+	style.FontSize = style.GetFontSize(fontSize)
+	style.GetTextOptions().WriteToRenderer(r)
+
+	x := DefaultHeaderPadding
+	if alignRight {
+		x = h.Width - DefaultHeaderPadding - r.MeasureText(body).Width()
+	}
+	r.Text(body, x, baseline)
+}
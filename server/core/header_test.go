@@ -0,0 +1,53 @@
+package core
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestHeaderRender(t *testing.T) {
+	assert := assert.New(t)
+
+	header := Header{
+		Width:       400,
+		Height:      DefaultHeaderHeight,
+		Title:       "Apple Inc. (AAPL)",
+		Subtitle:    "NASDAQ",
+		Value:       "150.25",
+		Change:      "+1.50 (+1.01%)",
+		ChangeColor: DefaultCandleUpColor,
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(header.Render(chart.SVG, buffer))
+	svg := buffer.String()
+
+	textX := regexp.MustCompile(`<text x="(\d+)" y="(\d+)"[^>]*>([^<]+)</text>`)
+	positions := map[string][]int{}
+	for _, matches := range textX.FindAllStringSubmatch(svg, -1) {
+		x, _ := strconv.Atoi(matches[1])
+		y, _ := strconv.Atoi(matches[2])
+		positions[matches[3]] = []int{x, y}
+	}
+	assert.Len(positions, 4)
+
+	assert.Equal(DefaultHeaderPadding, positions["Apple Inc. (AAPL)"][0])
+	assert.Equal(DefaultHeaderPadding, positions["NASDAQ"][0])
+	assert.True(positions["150.25"][0] > 200)
+	assert.True(positions["+1.50 (+1.01%)"][0] > 200)
+	assert.True(positions["Apple Inc. (AAPL)"][1] < positions["NASDAQ"][1], "the subtitle is below the title")
+	assert.Contains("fill:rgba(42,190,137,1.0)", svg)
+}
+
+func TestHeaderRenderEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(Header{Width: 400, Height: DefaultHeaderHeight, Title: "AAPL"}.Render(chart.SVG, buffer))
+	assert.Contains(">AAPL</text>", buffer.String())
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wcharczuk/go-chart"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// IndexRange is a continuous range over item indices, for charts that ignore time (i.e. renko bricks).
+// Each item is centered on its index, and ticks are labeled with the item's label rather than its index.
+type IndexRange struct {
+	chart.ContinuousRange
+	Labels []string
+}
+
+// NewIndexRange returns an index range spanning the labeled items, padded by half an item on either side.
+func NewIndexRange(labels []string) *IndexRange {
+	return &IndexRange{
+		ContinuousRange: chart.ContinuousRange{Min: -0.5, Max: float64(len(labels)) - 0.5},
+		Labels:          labels,
+	}
+}
+
+// String returns a simple string for the IndexRange.
+func (r IndexRange) String() string {
+	return fmt.Sprintf("IndexRange [%.2f,%.2f] => %d", r.Min, r.Max, r.Domain)
+}
+
+// GetTicks returns a tick for every nth item, where n is the smallest step that keeps the labels from overlapping.
+func (r *IndexRange) GetTicks(renderer chart.Renderer, defaults chart.Style, vf chart.ValueFormatter) []chart.Tick {
+	if len(r.Labels) == 0 {
+		return nil
+	}
+
+	defaults.GetTextOptions().WriteToRenderer(renderer)
+	var labelWidth int
+	for _, label := range r.Labels {
+		labelWidth = util.Math.MaxInt(labelWidth, renderer.MeasureText(label).Width())
+	}
+	maxTicks := util.Math.MaxInt(r.Domain/(labelWidth+chart.DefaultMinimumTickHorizontalSpacing), 1)
+	step := int(math.Ceil(float64(len(r.Labels)) / float64(maxTicks)))
+
+	var ticks []chart.Tick
+	for index := 0; index < len(r.Labels); index += step {
+		ticks = append(ticks, chart.Tick{Value: float64(index), Label: r.Labels[index]})
+	}
+	return ticks
+}
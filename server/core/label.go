@@ -0,0 +1,49 @@
+package core
+
+import (
+	"io"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+// DefaultLabelPadding is the gap (in pixels) between a label's text and the sides of its box.
+const DefaultLabelPadding = 4
+
+// Label is a single line of text, vertically centered in its box and aligned by its style (left by default).
+type Label struct {
+	Width  int
+	Height int
+	Text   string
+	Style  chart.Style
+}
+
+// Render implements Renderable.
+func (l Label) Render(rp chart.RendererProvider, w io.Writer) error {
+	r, err := rp(l.Width, l.Height)
+	if err != nil {
+		return err
+	}
+
+	style := l.Style
+	if style.Font == nil {
+		font, err := chart.GetDefaultFont()
+		if err != nil {
+			return err
+		}
+		style.Font = font
+	}
+	style.FontSize = style.GetFontSize(chart.DefaultFontSize)
+	style.FontColor = style.GetFontColor(chart.DefaultTextColor)
+	style.GetTextOptions().WriteToRenderer(r)
+
+	tb := r.MeasureText(l.Text)
+	x := DefaultLabelPadding
+	switch style.GetTextHorizontalAlign() {
+	case chart.TextHorizontalAlignCenter:
+		x = (l.Width - tb.Width()) >> 1
+	case chart.TextHorizontalAlignRight:
+		x = l.Width - DefaultLabelPadding - tb.Width()
+	}
+	r.Text(l.Text, x, (l.Height+tb.Height())>>1)
+	return r.Save(w)
+}
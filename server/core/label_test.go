@@ -0,0 +1,37 @@
+package core
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestLabelRender(t *testing.T) {
+	assert := assert.New(t)
+
+	textX := regexp.MustCompile(`<text x="(\d+)" y="(\d+)"`)
+	render := func(align chart.TextHorizontalAlign) (x, y int) {
+		buffer := bytes.NewBuffer(nil)
+		label := Label{Width: 200, Height: 30, Text: "+1.25%", Style: chart.Style{TextHorizontalAlign: align}}
+		assert.Nil(label.Render(chart.SVG, buffer))
+		assert.Contains(">+1.25%</text>", buffer.String())
+
+		matches := textX.FindStringSubmatch(buffer.String())
+		assert.Len(matches, 3)
+		x, _ = strconv.Atoi(matches[1])
+		y, _ = strconv.Atoi(matches[2])
+		return
+	}
+
+	left, y := render(chart.TextHorizontalAlignUnset)
+	assert.Equal(DefaultLabelPadding, left)
+	assert.True(y > 15 && y < 30)
+	center, _ := render(chart.TextHorizontalAlignCenter)
+	right, _ := render(chart.TextHorizontalAlignRight)
+	assert.True(left < center && center < right)
+	assert.True(right < 200-DefaultLabelPadding)
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+// Renderable is a type that can render itself as an image, such as a `chart.Chart`.
+type Renderable interface {
+	Render(rp chart.RendererProvider, w io.Writer) error
+}
+
+// Panel is a renderable placed at an offset within a layout.
+type Panel struct {
+	Top        int
+	Left       int
+	Renderable Renderable
+}
+
+// Layout composes multiple renderables into a single image.
+// Each panel is drawn into the same underlying renderer, offset by its position, so the
+// result is a single png or svg rather than a raster composite.
+type Layout struct {
+	Width  int
+	Height int
+	Panels []Panel
+
+	// Background fills the layout behind the panels, if set.
+	Background chart.Style
+}
+
+// Render implements Renderable.
+func (l Layout) Render(rp chart.RendererProvider, w io.Writer) error {
+	r, err := rp(l.Width, l.Height)
+	if err != nil {
+		return err
+	}
+
+	if !l.Background.FillColor.IsZero() {
+		chart.Draw.Box(r, chart.Box{Right: l.Width, Bottom: l.Height}, l.Background)
+	}
+	for _, panel := range l.Panels {
+		offset := &offsetRenderer{Renderer: r, dx: panel.Left, dy: panel.Top}
+		err = panel.Renderable.Render(func(_, _ int) (chart.Renderer, error) {
+			return offset, nil
+		}, ioutil.Discard)
+		if err != nil {
+			return err
+		}
+	}
+	return r.Save(w)
+}
+
+// offsetRenderer translates all drawing coordinates by a fixed offset and defers saving to the layout.
+type offsetRenderer struct {
+	chart.Renderer
+	dx, dy int
+}
+
+func (or *offsetRenderer) MoveTo(x, y int) {
+	or.Renderer.MoveTo(x+or.dx, y+or.dy)
+}
+
+func (or *offsetRenderer) LineTo(x, y int) {
+	or.Renderer.LineTo(x+or.dx, y+or.dy)
+}
+
+func (or *offsetRenderer) QuadCurveTo(cx, cy, x, y int) {
+	or.Renderer.QuadCurveTo(cx+or.dx, cy+or.dy, x+or.dx, y+or.dy)
+}
+
+func (or *offsetRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	or.Renderer.ArcTo(cx+or.dx, cy+or.dy, rx, ry, startAngle, delta)
+}
+
+func (or *offsetRenderer) Circle(radius float64, x, y int) {
+	or.Renderer.Circle(radius, x+or.dx, y+or.dy)
+}
+
+func (or *offsetRenderer) Text(body string, x, y int) {
+	or.Renderer.Text(body, x+or.dx, y+or.dy)
+}
+
+func (or *offsetRenderer) Save(w io.Writer) error {
+	return nil
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func testLayoutChart(height int) chart.Chart {
+	return chart.Chart{
+		Width:  200,
+		Height: height,
+		Series: []chart.Series{
+			chart.ContinuousSeries{
+				XValues: []float64{1, 2, 3},
+				YValues: []float64{1, 3, 2},
+			},
+		},
+	}
+}
+
+func TestLayoutRender(t *testing.T) {
+	assert := assert.New(t)
+
+	layout := Layout{
+		Width:  200,
+		Height: 300,
+		Panels: []Panel{
+			{Renderable: testLayoutChart(200)},
+			{Top: 200, Renderable: testLayoutChart(100)},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err := layout.Render(chart.SVG, buffer)
+	assert.Nil(err)
+
+	output := buffer.String()
+	assert.Equal(1, strings.Count(output, "<svg"))
+	assert.Equal(1, strings.Count(output, "</svg>"))
+	assert.Contains(`width="200" height="300"`, output)
+	// the second panel's background starts at its offset.
+	assert.Contains("M 0 200", output)
+}
+
+func TestLayoutRenderBackground(t *testing.T) {
+	assert := assert.New(t)
+
+	layout := Layout{
+		Width:      100,
+		Height:     60,
+		Background: chart.Style{FillColor: chart.ColorWhite},
+		Panels: []Panel{
+			{Top: 30, Renderable: Label{Width: 100, Height: 30, Text: "AAPL"}},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(layout.Render(chart.SVG, buffer))
+	assert.Contains("fill:rgba(255,255,255,1.0)", buffer.String())
+	assert.Contains(">AAPL</text>", buffer.String())
+}
+
+func TestOffsetRenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := chart.SVG(100, 100)
+	assert.Nil(err)
+	offset := &offsetRenderer{Renderer: r, dx: 10, dy: 20}
+	offset.MoveTo(1, 2)
+	offset.LineTo(3, 4)
+	offset.Stroke()
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(offset.Save(buffer))
+	assert.Zero(buffer.Len())
+
+	assert.Nil(r.Save(buffer))
+	assert.Contains("M 11 22", buffer.String())
+	assert.Contains("L 13 24", buffer.String())
+}
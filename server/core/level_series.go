@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+// Level is a horizontal reference line (Low == High) or a shaded band from Low to High.
+type Level struct {
+	Low   float64
+	High  float64
+	Label string
+}
+
+// IsLine returns if the level is a single price rather than a band.
+func (l Level) IsLine() bool {
+	return l.Low == l.High
+}
+
+// ParseLine parses a reference line as `value` or `value:label` (i.e. `150:Target`).
+func ParseLine(value string) (Level, error) {
+	price, label := splitLabel(value)
+	parsed, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return Level{}, fmt.Errorf("invalid line: %s", value)
+	}
+	return Level{Low: parsed, High: parsed, Label: label}, nil
+}
+
+// bandExpr matches `low-high`, where either bound may be negative (i.e. `-0.1--0.05` in percent change charts).
+var bandExpr = regexp.MustCompile(`^(-?[0-9.]+)-(-?[0-9.]+)$`)
+
+// ParseBand parses a shaded band as `low-high` or `low-high:label` (i.e. `140-160:Buy zone`).
+func ParseBand(value string) (Level, error) {
+	bounds, label := splitLabel(value)
+	matches := bandExpr.FindStringSubmatch(bounds)
+	if len(matches) != 3 {
+		return Level{}, fmt.Errorf("invalid band: %s", value)
+	}
+	low, lowErr := strconv.ParseFloat(matches[1], 64)
+	high, highErr := strconv.ParseFloat(matches[2], 64)
+	if lowErr != nil || highErr != nil || low >= high {
+		return Level{}, fmt.Errorf("invalid band: %s", value)
+	}
+	return Level{Low: low, High: high, Label: label}, nil
+}
+
+// splitLabel splits a value from its optional `:label` suffix.
+func splitLabel(value string) (string, string) {
+	if index := strings.Index(value, ":"); index >= 0 {
+		return strings.TrimSpace(value[:index]), strings.TrimSpace(value[index+1:])
+	}
+	return strings.TrimSpace(value), ""
+}
+
+// LevelSeries draws reference lines and bands across the full width of the canvas, labeled at the left.
+// Lines are dashed strokes; bands are filled with the fill color.
+type LevelSeries struct {
+	Name   string
+	Style  chart.Style
+	YAxis  chart.YAxisType
+	Levels []Level
+
+	// XValue is any x value within the chart's range; the levels span the canvas regardless, but they
+	// report it alongside their prices so the y range grows to include levels outside the data.
+	XValue float64
+}
+
+// GetName implements chart.Series.
+func (ls LevelSeries) GetName() string {
+	return ls.Name
+}
+
+// GetStyle implements chart.Series.
+func (ls LevelSeries) GetStyle() chart.Style {
+	return ls.Style
+}
+
+// GetYAxis implements chart.Series.
+func (ls LevelSeries) GetYAxis() chart.YAxisType {
+	return ls.YAxis
+}
+
+// Len implements chart.BoundedValuesProvider.
+func (ls LevelSeries) Len() int {
+	return len(ls.Levels)
+}
+
+// GetBoundedValues implements chart.BoundedValuesProvider.
+func (ls LevelSeries) GetBoundedValues(index int) (x, y0, y1 float64) {
+	return ls.XValue, ls.Levels[index].Low, ls.Levels[index].High
+}
+
+// Render implements chart.Series.
+func (ls LevelSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	style := ls.Style.InheritFrom(defaults)
+	if style.FontColor.IsZero() {
+		style.FontColor = chart.DefaultTextColor
+	}
+	cb := canvasBox.Bottom
+	for _, level := range ls.Levels {
+		top, bottom := cb-yrange.Translate(level.High), cb-yrange.Translate(level.Low)
+		if level.IsLine() {
+			chart.Style{
+				StrokeColor:     style.StrokeColor,
+				StrokeWidth:     style.GetStrokeWidth(),
+				StrokeDashArray: []float64{5.0, 5.0},
+			}.WriteToRenderer(r)
+			r.MoveTo(canvasBox.Left, top)
+			r.LineTo(canvasBox.Right, top)
+			r.Stroke()
+		} else {
+			chart.Draw.Box(r, chart.Box{Top: top, Left: canvasBox.Left, Right: canvasBox.Right, Bottom: bottom}, chart.Style{
+				FillColor:   style.FillColor,
+				StrokeColor: style.FillColor,
+				StrokeWidth: 1,
+			})
+		}
+
+		if len(level.Label) > 0 {
+			style.GetTextOptions().WriteToRenderer(r)
+			// labels sit just above lines (or below, if there isn't room), and just inside the top of bands.
+			textHeight := r.MeasureText(level.Label).Height()
+			y := top - DefaultLabelPadding
+			if !level.IsLine() || y-textHeight < canvasBox.Top {
+				y = top + DefaultLabelPadding + textHeight
+			}
+			r.Text(level.Label, canvasBox.Left+DefaultLabelPadding, y)
+		}
+	}
+}
+
+// Validate implements chart.Series.
+func (ls LevelSeries) Validate() error {
+	for _, level := range ls.Levels {
+		if level.Low > level.High {
+			return fmt.Errorf("level low must not be above its high")
+		}
+	}
+	return nil
+}
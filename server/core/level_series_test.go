@@ -0,0 +1,79 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+func TestParseLine(t *testing.T) {
+	assert := assert.New(t)
+
+	line, err := ParseLine("150:Target")
+	assert.Nil(err)
+	assert.Equal(Level{Low: 150, High: 150, Label: "Target"}, line)
+	assert.True(line.IsLine())
+
+	line, err = ParseLine("-0.05")
+	assert.Nil(err)
+	assert.Equal(-0.05, line.Low)
+	assert.Empty(line.Label)
+
+	_, err = ParseLine("target")
+	assert.NotNil(err)
+}
+
+func TestParseBand(t *testing.T) {
+	assert := assert.New(t)
+
+	band, err := ParseBand("140-160")
+	assert.Nil(err)
+	assert.Equal(Level{Low: 140, High: 160}, band)
+	assert.False(band.IsLine())
+
+	band, err = ParseBand("-0.1--0.05:Drawdown zone")
+	assert.Nil(err)
+	assert.Equal(Level{Low: -0.1, High: -0.05, Label: "Drawdown zone"}, band)
+
+	_, err = ParseBand("160-140")
+	assert.NotNil(err)
+	_, err = ParseBand("140")
+	assert.NotNil(err)
+}
+
+func TestLevelSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 03, 01, 12, 0, 0, 0, time.UTC)
+	prices := chart.TimeSeries{
+		Style:   chart.StyleShow(),
+		XValues: []time.Time{start, start.AddDate(0, 0, 10)},
+		YValues: []float64{10, 11},
+	}
+	levels := LevelSeries{
+		Style:  chart.Style{Show: true, StrokeColor: chart.ColorOrange, FillColor: chart.ColorOrange.WithAlpha(32)},
+		Levels: []Level{{Low: 20, High: 20, Label: "Target"}, {Low: 8, High: 9, Label: "Support"}},
+		XValue: util.Time.ToFloat64(start),
+	}
+	assert.Nil(levels.Validate())
+
+	// levels outside the data extend the y range.
+	_, low, high := levels.GetBoundedValues(0)
+	assert.Equal(20.0, low)
+	assert.Equal(20.0, high)
+
+	graph := chart.Chart{Width: 400, Height: 200, Series: []chart.Series{levels, prices}}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	assert.Contains(">Target</text>", buffer.String())
+	assert.Contains(">Support</text>", buffer.String())
+	// the target is the top of the y range, at the top of the canvas.
+	assert.Contains(`L 395 5" style="stroke-width:1;stroke:rgba(217,101,0,1.0)`, buffer.String())
+	assert.Contains("fill:rgba(51,51,51,1.0)", buffer.String())
+
+	assert.NotNil(LevelSeries{Levels: []Level{{Low: 2, High: 1}}}.Validate())
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wcharczuk/go-chart"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+var (
+	// logRangeMantissas are the per-decade tick sets to try, from sparsest to densest.
+	logRangeMantissas = [][]float64{
+		{1},
+		{1, 2, 5},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	}
+)
+
+// LogRange is a range that maps values logarithmically (base 10) into the domain.
+// Values that are less than or equal to zero cannot be represented and are clamped to the range minimum.
+type LogRange struct {
+	Min        float64
+	Max        float64
+	Domain     int
+	Descending bool
+}
+
+// IsDescending returns if the range is descending.
+func (r LogRange) IsDescending() bool {
+	return r.Descending
+}
+
+// IsZero returns if the LogRange has been set or not.
+func (r LogRange) IsZero() bool {
+	return (r.Min == 0 || math.IsNaN(r.Min)) &&
+		(r.Max == 0 || math.IsNaN(r.Max)) &&
+		r.Domain == 0
+}
+
+// GetMin gets the min value for the range.
+func (r LogRange) GetMin() float64 {
+	return r.Min
+}
+
+// SetMin sets the min value for the range.
+// Non-positive values are ignored, as they would be if the chart rounds the minimum down to zero.
+func (r *LogRange) SetMin(min float64) {
+	if min <= 0 || math.IsInf(min, 0) || math.IsNaN(min) {
+		return
+	}
+	r.Min = min
+}
+
+// GetMax returns the max value for the range.
+func (r LogRange) GetMax() float64 {
+	return r.Max
+}
+
+// SetMax sets the max value for the range.
+func (r *LogRange) SetMax(max float64) {
+	if max <= 0 || math.IsInf(max, 0) || math.IsNaN(max) {
+		return
+	}
+	r.Max = max
+}
+
+// GetDelta returns the difference between the min and max value.
+func (r LogRange) GetDelta() float64 {
+	return r.Max - r.Min
+}
+
+// GetDomain returns the range domain.
+func (r LogRange) GetDomain() int {
+	return r.Domain
+}
+
+// SetDomain sets the range domain.
+func (r *LogRange) SetDomain(domain int) {
+	r.Domain = domain
+}
+
+// String returns a simple string for the LogRange.
+func (r LogRange) String() string {
+	return fmt.Sprintf("LogRange [%.2f,%.2f] => %d", r.Min, r.Max, r.Domain)
+}
+
+// Translate maps a given value into the LogRange space.
+func (r LogRange) Translate(value float64) int {
+	// a flat series has nothing to spread over the domain, so it's drawn through the middle.
+	if r.Max <= r.Min {
+		return r.Domain / 2
+	}
+	if value < r.Min {
+		value = r.Min
+	}
+	logMin := math.Log10(r.Min)
+	ratio := (math.Log10(value) - logMin) / (math.Log10(r.Max) - logMin)
+
+	if r.IsDescending() {
+		return r.Domain - int(math.Ceil(ratio*float64(r.Domain)))
+	}
+	return int(math.Ceil(ratio * float64(r.Domain)))
+}
+
+// GetTicks returns 1-2-5 style ticks per decade, picking the densest set that fits the domain.
+// If the range is too narrow for a per-decade set to produce enough ticks, it falls back to continuous ticks.
+func (r *LogRange) GetTicks(renderer chart.Renderer, defaults chart.Style, vf chart.ValueFormatter) []chart.Tick {
+	if vf == nil {
+		vf = chart.FloatValueFormatter
+	}
+
+	defaults.GetTextOptions().WriteToRenderer(renderer)
+	labelHeight := renderer.MeasureText(vf(r.Max)).Height() + chart.DefaultMinimumTickVerticalSpacing
+	maxTicks := r.Domain / util.Math.MaxInt(labelHeight, 1)
+
+	var best []float64
+	for _, mantissas := range logRangeMantissas {
+		values := r.tickValues(mantissas)
+		if len(values) > maxTicks {
+			break
+		}
+		best = values
+	}
+
+	if len(best) < 3 {
+		return chart.GenerateContinuousTicks(renderer, r, true, defaults, vf)
+	}
+
+	ticks := make([]chart.Tick, len(best))
+	for index, value := range best {
+		ticks[index] = chart.Tick{Value: value, Label: vf(value)}
+	}
+	return ticks
+}
+
+func (r LogRange) tickValues(mantissas []float64) []float64 {
+	if r.Min <= 0 || r.Max <= r.Min {
+		return nil
+	}
+
+	var values []float64
+	firstDecade := int(math.Floor(math.Log10(r.Min)))
+	lastDecade := int(math.Ceil(math.Log10(r.Max)))
+	for decade := firstDecade; decade <= lastDecade; decade++ {
+		base := math.Pow(10, float64(decade))
+		for _, m := range mantissas {
+			value := m * base
+			if value >= r.Min && value <= r.Max {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestLogRangeTranslate(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &LogRange{Min: 1, Max: 1000, Domain: 300}
+	assert.Equal(0, r.Translate(1))
+	assert.Equal(100, r.Translate(10))
+	assert.Equal(200, r.Translate(100))
+	assert.Equal(300, r.Translate(1000))
+
+	// values below the minimum (including zero) clamp to the bottom.
+	assert.Equal(0, r.Translate(0))
+	assert.Equal(0, r.Translate(-5))
+
+	r.Descending = true
+	assert.Equal(300, r.Translate(1))
+	assert.Equal(0, r.Translate(1000))
+}
+
+func TestLogRangeTranslateFlat(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &LogRange{Min: 50, Max: 50, Domain: 300}
+	assert.Equal(150, r.Translate(50))
+	assert.Equal(150, r.Translate(10))
+
+	r.Descending = true
+	assert.Equal(150, r.Translate(50))
+}
+
+func TestLogRangeSetMinIgnoresNonPositive(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &LogRange{}
+	assert.True(r.IsZero())
+	r.SetMin(12.5)
+	r.SetMax(900)
+	r.SetMin(0)
+	r.SetMin(-100)
+	assert.Equal(12.5, r.GetMin())
+	assert.Equal(900.0, r.GetMax())
+	assert.False(r.IsZero())
+}
+
+func TestLogRangeTickValues(t *testing.T) {
+	assert := assert.New(t)
+
+	r := LogRange{Min: 8, Max: 600}
+	values := r.tickValues([]float64{1, 2, 5})
+	assert.Len(values, 6)
+	assert.InDelta(10, values[0], 0.0001)
+	assert.InDelta(20, values[1], 0.0001)
+	assert.InDelta(50, values[2], 0.0001)
+	assert.InDelta(100, values[3], 0.0001)
+	assert.InDelta(200, values[4], 0.0001)
+	assert.InDelta(500, values[5], 0.0001)
+}
+
+func TestLogRangeGetTicks(t *testing.T) {
+	assert := assert.New(t)
+
+	font, err := chart.GetDefaultFont()
+	assert.Nil(err)
+	r, err := chart.PNG(1024, 1024)
+	assert.Nil(err)
+	defaults := chart.Style{Font: font, FontSize: chart.DefaultAxisFontSize}
+
+	wide := &LogRange{Min: 2, Max: 900, Domain: 800}
+	ticks := wide.GetTicks(r, defaults, chart.FloatValueFormatter)
+	assert.NotEmpty(ticks)
+	for _, tick := range ticks {
+		assert.True(tick.Value >= wide.Min && tick.Value <= wide.Max)
+	}
+	assert.Equal("2.00", ticks[0].Label)
+
+	// a range that does not span enough of a decade falls back to continuous ticks.
+	narrow := &LogRange{Min: 101, Max: 108, Domain: 800}
+	ticks = narrow.GetTicks(r, defaults, chart.FloatValueFormatter)
+	assert.True(len(ticks) > 2)
+	assert.Equal(narrow.Min, ticks[0].Value)
+}
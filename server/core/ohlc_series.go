@@ -0,0 +1,83 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// OHLCSeries draws traditional open-high-low-close bars; a vertical line from the low to the high,
+// with the open as a tick to the left and the close as a tick to the right.
+// Bars that close above their open are drawn in the up color, and the rest in the down color.
+type OHLCSeries struct {
+	Name         string
+	Style        chart.Style
+	YAxis        chart.YAxisType
+	CandleValues []chart.CandleValue
+
+	UpColor   drawing.Color
+	DownColor drawing.Color
+}
+
+// GetName implements chart.Series.
+func (ohlc *OHLCSeries) GetName() string {
+	return ohlc.Name
+}
+
+// GetStyle implements chart.Series.
+func (ohlc *OHLCSeries) GetStyle() chart.Style {
+	return ohlc.Style
+}
+
+// GetYAxis implements chart.Series.
+func (ohlc *OHLCSeries) GetYAxis() chart.YAxisType {
+	return ohlc.YAxis
+}
+
+// Len implements chart.BoundedValuesProvider.
+func (ohlc *OHLCSeries) Len() int {
+	return len(ohlc.CandleValues)
+}
+
+// GetBoundedValues implements chart.BoundedValuesProvider.
+func (ohlc *OHLCSeries) GetBoundedValues(index int) (x, y0, y1 float64) {
+	value := ohlc.CandleValues[index]
+	return util.Time.ToFloat64(value.Timestamp), value.Low, value.High
+}
+
+// Render implements chart.Series.
+func (ohlc *OHLCSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	style := ohlc.Style.InheritFrom(defaults)
+	centers := CandleCenters(canvasBox, xrange, ohlc.CandleValues)
+	tickWidth := util.Math.MaxInt(CandleWidth(centers)>>1, 1)
+
+	cb := canvasBox.Bottom
+	for index, cv := range ohlc.CandleValues {
+		x := centers[index]
+		chart.Style{
+			StrokeColor: getUpDownColor(cv.Close >= cv.Open, ohlc.UpColor, ohlc.DownColor),
+			StrokeWidth: style.GetStrokeWidth(),
+		}.WriteToRenderer(r)
+
+		yopen, yclose := cb-yrange.Translate(cv.Open), cb-yrange.Translate(cv.Close)
+		r.MoveTo(x, cb-yrange.Translate(cv.High))
+		r.LineTo(x, cb-yrange.Translate(cv.Low))
+		r.Stroke()
+		r.MoveTo(x-tickWidth, yopen)
+		r.LineTo(x, yopen)
+		r.Stroke()
+		r.MoveTo(x, yclose)
+		r.LineTo(x+tickWidth, yclose)
+		r.Stroke()
+	}
+}
+
+// Validate implements chart.Series.
+func (ohlc *OHLCSeries) Validate() error {
+	if ohlc.CandleValues == nil {
+		return fmt.Errorf("ohlc series requires `CandleValues` to be set")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestOHLCSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 03, 01, 12, 0, 0, 0, time.UTC)
+	series := &OHLCSeries{
+		Style: chart.StyleShow(),
+		CandleValues: []chart.CandleValue{
+			{Timestamp: start, Open: 10, High: 12, Low: 9, Close: 11},
+			{Timestamp: start.AddDate(0, 0, 1), Open: 11, High: 11.5, Low: 8, Close: 9},
+		},
+	}
+	assert.Nil(series.Validate())
+	_, low, high := series.GetBoundedValues(0)
+	assert.Equal(9.0, low)
+	assert.Equal(12.0, high)
+
+	graph := chart.Chart{Width: 200, Height: 200, Series: []chart.Series{series}}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	// bars are strokes only, in the up and down colors.
+	assert.Contains("stroke:rgba(42,190,137,1.0)", buffer.String())
+	assert.Contains("stroke:rgba(217,0,116,1.0)", buffer.String())
+	assert.NotNil((&OHLCSeries{}).Validate())
+}
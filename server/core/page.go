@@ -0,0 +1,209 @@
+package core
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+// PageLink is a link in the page's navigation, i.e. to the chart in another timeframe.
+type PageLink struct {
+	Label  string
+	URL    string
+	Active bool
+}
+
+// Page is a self-contained html page for a chart: the image, embedded as svg, under links to other
+// timeframes, with an inline script that draws a crosshair and the values of the series under the cursor.
+// Nothing is loaded from elsewhere.
+type Page struct {
+	Title  string
+	Width  int
+	Height int
+	Image  Renderable
+	Links  []PageLink
+	// Style colors the page; the fill is the background, the font color the text and the stroke the crosshair.
+	Style chart.Style
+
+	// Table is the values shown under the cursor, the rows lining up with the x positions.
+	Table           SeriesTable
+	YValueFormatter chart.ValueFormatter
+	// Positions are filled in as the image renders; `Top` is the offset of the chart they're recorded for
+	// within the image, i.e. under a header.
+	Positions *Positions
+	Top       int
+}
+
+// pagePoint is a row of the table at its position in the image.
+type pagePoint struct {
+	X      int       `json:"x"`
+	Label  string    `json:"label"`
+	Values []*string `json:"values"`
+}
+
+// pageCanvas is the plot area of the chart within the image.
+type pageCanvas struct {
+	Top    int `json:"top"`
+	Left   int `json:"left"`
+	Right  int `json:"right"`
+	Bottom int `json:"bottom"`
+}
+
+// pageData is the data for the crosshair script.
+type pageData struct {
+	Canvas  pageCanvas  `json:"canvas"`
+	Columns []string    `json:"columns"`
+	Points  []pagePoint `json:"points"`
+}
+
+// Render implements Renderable; the image is rendered with the renderer provider, which should draw svg
+// with its text escaped (i.e. `SVG`), and embedded in the page.
+func (p Page) Render(rp chart.RendererProvider, w io.Writer) error {
+	image := bytes.NewBuffer(nil)
+	if err := p.Image.Render(rp, image); err != nil {
+		return err
+	}
+
+	data := pageData{Points: []pagePoint{}}
+	if len(p.Table.Columns) > 0 {
+		data.Columns = p.Table.Columns[1:]
+	}
+	if p.Positions != nil {
+		canvas := p.Positions.Canvas
+		data.Canvas = pageCanvas{Top: canvas.Top + p.Top, Left: canvas.Left, Right: canvas.Right, Bottom: canvas.Bottom + p.Top}
+		for index, row := range p.Table.Rows {
+			if index >= len(p.Positions.X) {
+				break
+			}
+			point := pagePoint{X: p.Positions.X[index], Label: row.X, Values: make([]*string, len(row.Values))}
+			for column, value := range row.Values {
+				if value != nil {
+					formatted := p.getYValueFormatter()(*value)
+					point.Values[column] = &formatted
+				}
+			}
+			data.Points = append(data.Points, point)
+		}
+	}
+
+	return pageTemplate.Execute(w, map[string]interface{}{
+		"Title":      p.Title,
+		"Width":      p.Width,
+		"Height":     p.Height,
+		"Links":      p.Links,
+		"Background": hexColor(p.Style.FillColor),
+		"Text":       hexColor(p.Style.FontColor),
+		"Crosshair":  hexColor(p.Style.StrokeColor),
+		"Image":      template.HTML(image.String()),
+		"Data":       data,
+	})
+}
+
+func (p Page) getYValueFormatter() chart.ValueFormatter {
+	if p.YValueFormatter != nil {
+		return p.YValueFormatter
+	}
+	return chart.FloatValueFormatter
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { margin: 16px; font: 13px -apple-system, "Helvetica Neue", Arial, sans-serif; background: {{.Background}}; color: {{.Text}}; }
+nav { margin-bottom: 8px; }
+nav a { color: inherit; margin-right: 12px; text-decoration: none; opacity: 0.6; }
+nav a.active { font-weight: bold; opacity: 1; }
+#chart { position: relative; }
+#chart svg { display: block; }
+.crosshair { position: absolute; display: none; pointer-events: none; background: {{.Crosshair}}; }
+#tooltip { position: absolute; display: none; pointer-events: none; padding: 4px 8px; white-space: nowrap; background: {{.Background}}; border: 1px solid {{.Crosshair}}; }
+#tooltip b { display: block; }
+</style>
+</head>
+<body>
+<nav>{{range .Links}}<a href="{{.URL}}"{{if .Active}} class="active"{{end}}>{{.Label}}</a>{{end}}</nav>
+<div id="chart" style="width: {{.Width}}px; height: {{.Height}}px">
+{{.Image}}
+<div id="crosshair-x" class="crosshair" style="width: 1px"></div>
+<div id="crosshair-y" class="crosshair" style="height: 1px"></div>
+<div id="tooltip"></div>
+</div>
+<script>
+(function() {
+	var data = {{.Data}};
+	var chart = document.getElementById("chart");
+	var crosshairX = document.getElementById("crosshair-x");
+	var crosshairY = document.getElementById("crosshair-y");
+	var tooltip = document.getElementById("tooltip");
+
+	function hide() {
+		crosshairX.style.display = crosshairY.style.display = tooltip.style.display = "none";
+	}
+
+	// nearest returns the point closest to x; the points are in order.
+	function nearest(x) {
+		var low = 0, high = data.points.length - 1;
+		while (low < high) {
+			var mid = (low + high) >> 1;
+			if (data.points[mid].x < x) {
+				low = mid + 1;
+			} else {
+				high = mid;
+			}
+		}
+		if (low > 0 && x - data.points[low - 1].x < data.points[low].x - x) {
+			low--;
+		}
+		return data.points[low];
+	}
+
+	chart.addEventListener("mousemove", function(e) {
+		var bounds = chart.getBoundingClientRect();
+		var x = e.clientX - bounds.left, y = e.clientY - bounds.top, canvas = data.canvas;
+		if (data.points.length === 0 || x < canvas.left || x > canvas.right || y < canvas.top || y > canvas.bottom) {
+			hide();
+			return;
+		}
+		var point = nearest(x);
+
+		crosshairX.style.left = point.x + "px";
+		crosshairX.style.top = canvas.top + "px";
+		crosshairX.style.height = (canvas.bottom - canvas.top) + "px";
+		crosshairY.style.left = canvas.left + "px";
+		crosshairY.style.top = y + "px";
+		crosshairY.style.width = (canvas.right - canvas.left) + "px";
+
+		while (tooltip.firstChild) {
+			tooltip.removeChild(tooltip.firstChild);
+		}
+		var label = document.createElement("b");
+		label.textContent = point.label;
+		tooltip.appendChild(label);
+		for (var index = 0; index < data.columns.length; index++) {
+			if (point.values[index] === null) {
+				continue;
+			}
+			var line = document.createElement("div");
+			line.textContent = data.columns[index] + ": " + point.values[index];
+			tooltip.appendChild(line);
+		}
+
+		crosshairX.style.display = crosshairY.style.display = tooltip.style.display = "block";
+		var left = point.x + 12;
+		if (left + tooltip.offsetWidth > canvas.right) {
+			left = point.x - 12 - tooltip.offsetWidth;
+		}
+		tooltip.style.left = left + "px";
+		tooltip.style.top = canvas.top + "px";
+	});
+	chart.addEventListener("mouseleave", hide);
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestPositionSeries(t *testing.T) {
+	assert := assert.New(t)
+
+	positions := &Positions{}
+	graph := chart.Chart{
+		Width:  200,
+		Height: 100,
+		Series: []chart.Series{
+			chart.ContinuousSeries{Style: chart.StyleShow(), XValues: []float64{0, 10}, YValues: []float64{1, 2}},
+			PositionSeries{XValues: []float64{0, 5, 10}, Positions: positions},
+		},
+	}
+	assert.Nil(graph.Render(chart.SVG, bytes.NewBuffer(nil)))
+	assert.False(positions.Canvas.IsZero())
+	assert.Len(positions.X, 3)
+	assert.Equal(positions.Canvas.Left, positions.X[0])
+	assert.Equal(positions.Canvas.Right, positions.X[2])
+	assert.True(positions.X[0] < positions.X[1] && positions.X[1] < positions.X[2])
+}
+
+func TestPageRender(t *testing.T) {
+	assert := assert.New(t)
+
+	price := chart.ContinuousSeries{Name: "TEST", Style: chart.StyleShow(), XValues: []float64{0, 10}, YValues: []float64{1, 2}}
+	table := NewSeriesTable("", func(v interface{}) string { return "day" }, price)
+	positions := &Positions{}
+	graph := chart.Chart{
+		Width:  200,
+		Height: 100,
+		Series: []chart.Series{price, PositionSeries{XValues: []float64{0, 10}, Positions: positions}},
+	}
+	page := Page{
+		Title:     "<TEST>",
+		Width:     200,
+		Height:    100,
+		Image:     graph,
+		Links:     []PageLink{{Label: "1D", URL: "/stock/chart/TEST/1d?a=1&b=2"}, {Label: "LTM", URL: "/stock/chart/TEST/ltm", Active: true}},
+		Style:     chart.Style{FillColor: chart.ColorWhite, FontColor: chart.ColorBlack, StrokeColor: chart.ColorBlack},
+		Table:     table,
+		Positions: positions,
+		Top:       10,
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(page.Render(chart.SVG, buffer))
+	html := buffer.String()
+	assert.Contains("<title>&lt;TEST&gt;</title>", html)
+	assert.Contains(`<svg xmlns="http://www.w3.org/2000/svg"`, html)
+	assert.Contains(`<a href="/stock/chart/TEST/1d?a=1&amp;b=2">1D</a>`, html)
+	assert.Contains(`class="active">LTM</a>`, html)
+	assert.Contains("background: #ffffff", html)
+	assert.Contains(`"columns":["TEST"]`, html)
+	assert.Contains(`"label":"day","values":["1.00"]`, html)
+	assert.Contains(`"top":`+strconv.Itoa(positions.Canvas.Top+10), html, "the canvas is offset within the image")
+	assert.False(strings.Contains(html, "ZgotmplZ"), "nothing in the template is filtered")
+}
+
+func TestPageRenderEscapesText(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 03, 01, 12, 0, 0, 0, time.UTC)
+	price := chart.TimeSeries{Name: "TEST", Style: chart.StyleShow(), XValues: []time.Time{start, start.AddDate(0, 0, 10)}, YValues: []float64{1, 2}}
+	graph := chart.Chart{
+		Width:  400,
+		Height: 200,
+		Series: []chart.Series{
+			price,
+			LevelSeries{Style: chart.StyleShow(), Levels: []Level{{Low: 1.5, High: 1.5, Label: "<script>alert(2)</script>"}}},
+			EventSeries{Style: chart.StyleShow(), Events: []Event{{Timestamp: start.AddDate(0, 0, 5), Label: `<a href="x">event</a>`}}},
+		},
+	}
+	image := Layout{
+		Width:  400,
+		Height: 200 + DefaultHeaderHeight,
+		Panels: []Panel{
+			{Renderable: Header{Width: 400, Height: DefaultHeaderHeight, Title: "<img src=x onerror=alert(1)>"}},
+			{Top: DefaultHeaderHeight, Renderable: graph},
+		},
+	}
+	page := Page{Title: "TEST", Width: 400, Height: 200 + DefaultHeaderHeight, Image: image}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(page.Render(SVG, buffer))
+	html := buffer.String()
+	assert.Contains("&lt;img src=x onerror=alert(1)&gt;", html)
+	assert.Contains("&lt;script&gt;alert(2)&lt;/script&gt;", html)
+	assert.Contains("&lt;a href=&#34;x&#34;&gt;event&lt;/a&gt;", html)
+	assert.False(strings.Contains(html, "<img"))
+	assert.False(strings.Contains(html, "<script>alert"))
+	assert.False(strings.Contains(html, `<a href="x"`))
+}
@@ -0,0 +1,498 @@
+package core
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	"github.com/wcharczuk/go-chart/roboto"
+	util "github.com/wcharczuk/go-chart/util"
+	"golang.org/x/image/font"
+)
+
+const (
+	// pdfPointsPerPixel maps chart pixels onto pdf points, treating a pixel as 1/96th of an inch.
+	pdfPointsPerPixel = 72.0 / 96.0
+	// pdfArcSegments is the number of line segments used per full turn of an arc.
+	pdfArcSegments = 64
+)
+
+// PDF returns a vector renderer that writes a single page pdf, for use wherever `chart.PNG` or `chart.SVG` is.
+// The page is the size of the chart, and text is set in Roboto (the font go-chart draws with), embedded in the file.
+func PDF(width, height int) (chart.Renderer, error) {
+	f, err := getPDFFont()
+	if err != nil {
+		return nil, err
+	}
+	return &pdfRenderer{
+		width:   width,
+		height:  height,
+		dpi:     chart.DefaultDPI,
+		font:    f,
+		content: bytes.NewBuffer(nil),
+		path:    bytes.NewBuffer(nil),
+		alphas:  map[[2]uint8]string{},
+		glyphs:  map[truetype.Index]rune{},
+	}, nil
+}
+
+// pdfRenderer records drawing commands as a pdf content stream, in pixel coordinates with y down.
+type pdfRenderer struct {
+	width, height int
+	dpi           float64
+	font          *pdfFont
+	style         chart.Style
+	textTheta     *float64
+
+	content *bytes.Buffer
+	// path holds the path under construction; pdf requires a path to be painted
+	// before any state changes, so it's written out when it's stroked or filled.
+	path     *bytes.Buffer
+	x, y     float64
+	hasPoint bool
+	// alphas are the graphics states used for transparent colors, by stroke and fill alpha.
+	alphas map[[2]uint8]string
+	// glyphs are the glyphs used by text, and the characters they were used for.
+	glyphs map[truetype.Index]rune
+}
+
+// ResetStyle implements chart.Renderer.
+func (pr *pdfRenderer) ResetStyle() {
+	pr.style = chart.Style{Font: pr.style.Font}
+	pr.textTheta = nil
+}
+
+// GetDPI implements chart.Renderer.
+func (pr *pdfRenderer) GetDPI() float64 {
+	return pr.dpi
+}
+
+// SetDPI implements chart.Renderer.
+func (pr *pdfRenderer) SetDPI(dpi float64) {
+	pr.dpi = dpi
+}
+
+// SetStrokeColor implements chart.Renderer.
+func (pr *pdfRenderer) SetStrokeColor(c drawing.Color) {
+	pr.style.StrokeColor = c
+}
+
+// SetFillColor implements chart.Renderer.
+func (pr *pdfRenderer) SetFillColor(c drawing.Color) {
+	pr.style.FillColor = c
+}
+
+// SetStrokeWidth implements chart.Renderer.
+func (pr *pdfRenderer) SetStrokeWidth(width float64) {
+	pr.style.StrokeWidth = width
+}
+
+// SetStrokeDashArray implements chart.Renderer.
+func (pr *pdfRenderer) SetStrokeDashArray(dashArray []float64) {
+	pr.style.StrokeDashArray = dashArray
+}
+
+// MoveTo implements chart.Renderer.
+func (pr *pdfRenderer) MoveTo(x, y int) {
+	pr.moveTo(float64(x), float64(y))
+}
+
+// LineTo implements chart.Renderer.
+func (pr *pdfRenderer) LineTo(x, y int) {
+	pr.lineTo(float64(x), float64(y))
+}
+
+// QuadCurveTo implements chart.Renderer; pdf only has cubic curves, so the control point is raised to two.
+func (pr *pdfRenderer) QuadCurveTo(cx, cy, x, y int) {
+	qx, qy, ex, ey := float64(cx), float64(cy), float64(x), float64(y)
+	fmt.Fprintf(pr.path, "%s %s %s %s %s %s c\n",
+		pdfNumber(pr.x+2.0/3.0*(qx-pr.x)), pdfNumber(pr.y+2.0/3.0*(qy-pr.y)),
+		pdfNumber(ex+2.0/3.0*(qx-ex)), pdfNumber(ey+2.0/3.0*(qy-ey)),
+		pdfNumber(ex), pdfNumber(ey))
+	pr.x, pr.y = ex, ey
+}
+
+// ArcTo implements chart.Renderer, approximating the arc with line segments.
+func (pr *pdfRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	segments := util.Math.MaxInt(int(math.Abs(delta)/(2*math.Pi)*pdfArcSegments+0.5), 1)
+	for index := 0; index <= segments; index++ {
+		angle := startAngle + delta*float64(index)/float64(segments)
+		x, y := float64(cx)+math.Cos(angle)*rx, float64(cy)+math.Sin(angle)*ry
+		if index == 0 && !pr.hasPoint {
+			pr.moveTo(x, y)
+		} else {
+			pr.lineTo(x, y)
+		}
+	}
+}
+
+// Close implements chart.Renderer.
+func (pr *pdfRenderer) Close() {
+	pr.path.WriteString("h\n")
+}
+
+// Stroke implements chart.Renderer.
+func (pr *pdfRenderer) Stroke() {
+	pr.paint(false, true)
+}
+
+// Fill implements chart.Renderer.
+func (pr *pdfRenderer) Fill() {
+	pr.paint(true, false)
+}
+
+// FillStroke implements chart.Renderer.
+func (pr *pdfRenderer) FillStroke() {
+	pr.paint(true, true)
+}
+
+// Circle implements chart.Renderer; like the raster renderer it adds the circle to the path without painting it.
+func (pr *pdfRenderer) Circle(radius float64, x, y int) {
+	r := int(radius)
+	pr.MoveTo(x-r, y)
+	pr.QuadCurveTo(x-r, y-r, x, y-r)
+	pr.QuadCurveTo(x+r, y-r, x+r, y)
+	pr.QuadCurveTo(x+r, y+r, x, y+r)
+	pr.QuadCurveTo(x-r, y+r, x-r, y)
+}
+
+// SetFont implements chart.Renderer; text is always set in the embedded font, but the
+// font is still used to measure text.
+func (pr *pdfRenderer) SetFont(f *truetype.Font) {
+	pr.style.Font = f
+}
+
+// SetFontColor implements chart.Renderer.
+func (pr *pdfRenderer) SetFontColor(c drawing.Color) {
+	pr.style.FontColor = c
+}
+
+// SetFontSize implements chart.Renderer.
+func (pr *pdfRenderer) SetFontSize(size float64) {
+	pr.style.FontSize = size
+}
+
+// Text implements chart.Renderer; (x, y) is the left of the baseline.
+func (pr *pdfRenderer) Text(body string, x, y int) {
+	if pr.style.FontColor.IsTransparent() || len(body) == 0 {
+		return
+	}
+	// text space has y up, so the text matrix flips it back (and applies any rotation about the origin).
+	theta := 0.0
+	if pr.textTheta != nil {
+		theta = *pr.textTheta
+	}
+	sin, cos := math.Sin(theta), math.Cos(theta)
+
+	pr.content.WriteString("q\n")
+	fmt.Fprintf(pr.content, "%s rg\n", pdfColor(pr.style.FontColor))
+	pr.writeAlpha(255, pr.style.FontColor.A)
+	fmt.Fprintf(pr.content, "BT /F1 %s Tf %s %s %s %s %d %d Tm <%s> Tj ET\nQ\n",
+		pdfNumber(pr.getFontSizePixels()),
+		pdfNumber(cos), pdfNumber(sin), pdfNumber(sin), pdfNumber(-cos), x, y,
+		pr.encode(body))
+}
+
+// MeasureText implements chart.Renderer, measuring text the same way the svg renderer does.
+func (pr *pdfRenderer) MeasureText(body string) chart.Box {
+	f := pr.style.GetFont()
+	if f == nil {
+		f = pr.font.font
+	}
+	drawer := &font.Drawer{
+		Face: truetype.NewFace(f, &truetype.Options{DPI: pr.dpi, Size: pr.style.GetFontSize()}),
+	}
+	box := chart.Box{Right: drawer.MeasureString(body).Ceil(), Bottom: int(pr.getFontSizePixels())}
+	if pr.textTheta == nil {
+		return box
+	}
+	return box.Corners().Rotate(util.Math.RadiansToDegrees(*pr.textTheta)).Box()
+}
+
+// SetTextRotation implements chart.Renderer.
+func (pr *pdfRenderer) SetTextRotation(radians float64) {
+	pr.textTheta = &radians
+}
+
+// ClearTextRotation implements chart.Renderer.
+func (pr *pdfRenderer) ClearTextRotation() {
+	pr.textTheta = nil
+}
+
+// Save implements chart.Renderer, writing the document.
+func (pr *pdfRenderer) Save(w io.Writer) error {
+	pageWidth, pageHeight := float64(pr.width)*pdfPointsPerPixel, float64(pr.height)*pdfPointsPerPixel
+	// the page transform maps pixels (y down) onto points (y up).
+	content := bytes.NewBuffer(nil)
+	fmt.Fprintf(content, "%s 0 0 %s 0 %s cm\n", pdfNumber(pdfPointsPerPixel), pdfNumber(-pdfPointsPerPixel), pdfNumber(pageHeight))
+	content.Write(pr.content.Bytes())
+	compressed, err := pdfCompress(content.Bytes())
+	if err != nil {
+		return err
+	}
+
+	alphas := make([]string, 0, len(pr.alphas))
+	for alpha, name := range pr.alphas {
+		alphas = append(alphas, fmt.Sprintf("/%s << /Type /ExtGState /CA %s /ca %s >>", name, pdfNumber(float64(alpha[0])/255.0), pdfNumber(float64(alpha[1])/255.0)))
+	}
+	sort.Strings(alphas)
+
+	doc := &pdfDocument{buffer: bytes.NewBuffer(nil)}
+	doc.buffer.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	doc.object("<< /Type /Catalog /Pages 2 0 R >>")
+	doc.object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	doc.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> /ExtGState << %s >> >> >>",
+		pdfNumber(pageWidth), pdfNumber(pageHeight), strings.Join(alphas, " ")))
+	doc.stream(fmt.Sprintf("/Length %d /Filter /FlateDecode", len(compressed)), compressed)
+	// text is written as glyph ids, so any character in the font can be set.
+	doc.object(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [6 0 R] /ToUnicode 9 0 R >>", pr.font.name))
+	doc.object(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor 7 0 R /DW 0 /W [%s] /CIDToGIDMap /Identity >>",
+		pr.font.name, pr.getWidths()))
+	doc.object(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 8 0 R >>",
+		pr.font.name, pr.font.bounds[0], pr.font.bounds[1], pr.font.bounds[2], pr.font.bounds[3], pr.font.bounds[3], pr.font.bounds[1], pr.font.bounds[3]))
+	doc.stream(fmt.Sprintf("/Length %d /Length1 %d /Filter /FlateDecode", len(pr.font.file), len(roboto.Roboto)), pr.font.file)
+	toUnicode := pr.getToUnicode()
+	doc.stream(fmt.Sprintf("/Length %d", len(toUnicode)), []byte(toUnicode))
+
+	xref := doc.buffer.Len()
+	fmt.Fprintf(doc.buffer, "xref\n0 %d\n0000000000 65535 f \n", len(doc.offsets)+1)
+	for _, offset := range doc.offsets {
+		fmt.Fprintf(doc.buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(doc.buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(doc.offsets)+1, xref)
+
+	_, err = w.Write(doc.buffer.Bytes())
+	return err
+}
+
+func (pr *pdfRenderer) moveTo(x, y float64) {
+	fmt.Fprintf(pr.path, "%s %s m\n", pdfNumber(x), pdfNumber(y))
+	pr.x, pr.y, pr.hasPoint = x, y, true
+}
+
+func (pr *pdfRenderer) lineTo(x, y float64) {
+	if !pr.hasPoint {
+		pr.moveTo(x, y)
+		return
+	}
+	fmt.Fprintf(pr.path, "%s %s l\n", pdfNumber(x), pdfNumber(y))
+	pr.x, pr.y = x, y
+}
+
+// paint writes the current path with the current style and starts a new path.
+func (pr *pdfRenderer) paint(fill, stroke bool) {
+	defer func() {
+		pr.path.Reset()
+		pr.hasPoint = false
+	}()
+	fill = fill && !pr.style.FillColor.IsTransparent()
+	stroke = stroke && !pr.style.StrokeColor.IsTransparent() && pr.style.StrokeWidth > 0
+	if pr.path.Len() == 0 || (!fill && !stroke) {
+		return
+	}
+
+	pr.content.WriteString("q\n")
+	strokeAlpha, fillAlpha := uint8(255), uint8(255)
+	if fill {
+		fmt.Fprintf(pr.content, "%s rg\n", pdfColor(pr.style.FillColor))
+		fillAlpha = pr.style.FillColor.A
+	}
+	if stroke {
+		fmt.Fprintf(pr.content, "%s RG %s w 1 J 1 j\n", pdfColor(pr.style.StrokeColor), pdfNumber(pr.style.StrokeWidth))
+		strokeAlpha = pr.style.StrokeColor.A
+		if len(pr.style.StrokeDashArray) > 0 {
+			dashes := make([]string, len(pr.style.StrokeDashArray))
+			for index, dash := range pr.style.StrokeDashArray {
+				dashes[index] = pdfNumber(dash)
+			}
+			fmt.Fprintf(pr.content, "[%s] 0 d\n", strings.Join(dashes, " "))
+		}
+	}
+	pr.writeAlpha(strokeAlpha, fillAlpha)
+	pr.content.Write(pr.path.Bytes())
+	switch {
+	case fill && stroke:
+		pr.content.WriteString("B\nQ\n")
+	case fill:
+		pr.content.WriteString("f\nQ\n")
+	default:
+		pr.content.WriteString("S\nQ\n")
+	}
+}
+
+// writeAlpha sets the stroke and fill opacity of the graphics state.
+func (pr *pdfRenderer) writeAlpha(strokeAlpha, fillAlpha uint8) {
+	if strokeAlpha == 255 && fillAlpha == 255 {
+		return
+	}
+	alpha := [2]uint8{strokeAlpha, fillAlpha}
+	name, hasName := pr.alphas[alpha]
+	if !hasName {
+		name = fmt.Sprintf("GS%d", len(pr.alphas)+1)
+		pr.alphas[alpha] = name
+	}
+	fmt.Fprintf(pr.content, "/%s gs\n", name)
+}
+
+func (pr *pdfRenderer) getFontSizePixels() float64 {
+	return drawing.PointsToPixels(pr.dpi, pr.style.GetFontSize())
+}
+
+// pdfDocument writes numbered objects, recording their offsets for the cross reference table.
+type pdfDocument struct {
+	buffer  *bytes.Buffer
+	offsets []int
+}
+
+func (pd *pdfDocument) object(body string) {
+	pd.offsets = append(pd.offsets, pd.buffer.Len())
+	fmt.Fprintf(pd.buffer, "%d 0 obj\n%s\nendobj\n", len(pd.offsets), body)
+}
+
+func (pd *pdfDocument) stream(dictionary string, contents []byte) {
+	pd.offsets = append(pd.offsets, pd.buffer.Len())
+	fmt.Fprintf(pd.buffer, "%d 0 obj\n<< %s >>\nstream\n", len(pd.offsets), dictionary)
+	pd.buffer.Write(contents)
+	pd.buffer.WriteString("\nendstream\nendobj\n")
+}
+
+// pdfFont is the embedded font and the metrics pdf readers need to lay out text with it.
+type pdfFont struct {
+	font *truetype.Font
+	name string
+	// bounds are the font bounding box (x min, y min, x max, y max) in thousandths of an em.
+	bounds [4]int
+	// file is the compressed font file.
+	file []byte
+}
+
+var (
+	pdfFontOnce sync.Once
+	pdfFontErr  error
+	_pdfFont    *pdfFont
+)
+
+// getPDFFont loads the embedded font once, as every document embeds the same file.
+func getPDFFont() (*pdfFont, error) {
+	pdfFontOnce.Do(func() {
+		f, err := truetype.Parse(roboto.Roboto)
+		if err != nil {
+			pdfFontErr = err
+			return
+		}
+		file, err := pdfCompress(roboto.Roboto)
+		if err != nil {
+			pdfFontErr = err
+			return
+		}
+		name := strings.Replace(f.Name(truetype.NameIDPostscriptName), " ", "", -1)
+		if len(name) == 0 {
+			name = "Roboto-Medium"
+		}
+		bounds := f.Bounds(1000)
+		_pdfFont = &pdfFont{
+			font:   f,
+			name:   name,
+			bounds: [4]int{int(bounds.Min.X), int(bounds.Min.Y), int(bounds.Max.X), int(bounds.Max.Y)},
+			file:   file,
+		}
+	})
+	return _pdfFont, pdfFontErr
+}
+
+// encode returns text as a hex string of glyph ids, recording the glyphs used.
+func (pr *pdfRenderer) encode(text string) string {
+	encoded := bytes.NewBuffer(nil)
+	for _, r := range text {
+		glyph := pr.font.font.Index(r)
+		if _, hasGlyph := pr.glyphs[glyph]; !hasGlyph {
+			pr.glyphs[glyph] = r
+		}
+		fmt.Fprintf(encoded, "%04x", uint16(glyph))
+	}
+	return encoded.String()
+}
+
+// sortedGlyphs returns the glyphs used, in order.
+func (pr *pdfRenderer) sortedGlyphs() []truetype.Index {
+	ids := make([]int, 0, len(pr.glyphs))
+	for glyph := range pr.glyphs {
+		ids = append(ids, int(glyph))
+	}
+	sort.Ints(ids)
+	glyphs := make([]truetype.Index, len(ids))
+	for index, id := range ids {
+		glyphs[index] = truetype.Index(id)
+	}
+	return glyphs
+}
+
+// getWidths returns the advance widths of the glyphs used, in thousandths of an em.
+func (pr *pdfRenderer) getWidths() string {
+	var widths []string
+	for _, glyph := range pr.sortedGlyphs() {
+		widths = append(widths, fmt.Sprintf("%d [%d]", glyph, int(pr.font.font.HMetric(1000, glyph).AdvanceWidth)))
+	}
+	return strings.Join(widths, " ")
+}
+
+// getToUnicode returns a character map from the glyphs used back to text, so text can be searched and copied.
+func (pr *pdfRenderer) getToUnicode() string {
+	cmap := bytes.NewBuffer(nil)
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	cmap.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	cmap.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	cmap.WriteString("1 begincodespacerange\n<0000> <ffff>\nendcodespacerange\n")
+	glyphs := pr.sortedGlyphs()
+	// character maps allow at most 100 mappings per block.
+	for start := 0; start < len(glyphs); start += 100 {
+		end := util.Math.MinInt(start+100, len(glyphs))
+		fmt.Fprintf(cmap, "%d beginbfchar\n", end-start)
+		for _, glyph := range glyphs[start:end] {
+			fmt.Fprintf(cmap, "<%04x> <", uint16(glyph))
+			for _, unit := range utf16.Encode([]rune{pr.glyphs[glyph]}) {
+				fmt.Fprintf(cmap, "%04x", unit)
+			}
+			cmap.WriteString(">\n")
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return cmap.String()
+}
+
+// pdfNumber formats a number to at most three decimal places; pdf doesn't allow exponents.
+func pdfNumber(value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', 3, 64)
+	formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	if formatted == "-0" {
+		return "0"
+	}
+	return formatted
+}
+
+func pdfColor(c drawing.Color) string {
+	return fmt.Sprintf("%s %s %s", pdfNumber(float64(c.R)/255.0), pdfNumber(float64(c.G)/255.0), pdfNumber(float64(c.B)/255.0))
+}
+
+func pdfCompress(contents []byte) ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	writer := zlib.NewWriter(buffer)
+	if _, err := writer.Write(contents); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+// readPDFStream returns the decompressed contents of a stream object.
+func readPDFStream(assert *assert.Assertions, document []byte, object int) string {
+	start := bytes.Index(document, []byte(strconv.Itoa(object)+" 0 obj\n"))
+	assert.True(start >= 0)
+	start += bytes.Index(document[start:], []byte("stream\n")) + len("stream\n")
+	end := start + bytes.Index(document[start:], []byte("\nendstream"))
+
+	reader, err := zlib.NewReader(bytes.NewReader(document[start:end]))
+	assert.Nil(err)
+	contents, err := ioutil.ReadAll(reader)
+	assert.Nil(err)
+	return string(contents)
+}
+
+func TestPDFRender(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := chart.Chart{
+		Width:  400,
+		Height: 200,
+		Series: []chart.Series{
+			chart.ContinuousSeries{
+				Name:    "Price (σ)",
+				XValues: []float64{1, 2, 3},
+				YValues: []float64{10, 12, 11},
+			},
+		},
+	}
+	graph.Elements = []chart.Renderable{chart.Legend(&graph)}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(PDF, buffer))
+	document := buffer.Bytes()
+	assert.True(bytes.HasPrefix(document, []byte("%PDF-1.4\n")))
+	assert.True(bytes.HasSuffix(document, []byte("%%EOF\n")))
+	assert.Contains("/MediaBox [0 0 300 150]", string(document))
+
+	// every entry in the cross reference table points at its object.
+	xref, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(string(document))[1])
+	assert.Nil(err)
+	assert.True(bytes.HasPrefix(document[xref:], []byte("xref\n0 10\n")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(string(document[xref:]), -1)
+	assert.Len(offsets, 9)
+	for index, offset := range offsets {
+		position, _ := strconv.Atoi(offset[1])
+		assert.True(bytes.HasPrefix(document[position:], []byte(strconv.Itoa(index+1)+" 0 obj\n")), offset[1])
+	}
+
+	content := readPDFStream(assert, document, 4)
+	assert.True(bytes.HasPrefix([]byte(content), []byte("0.75 0 0 -0.75 0 150 cm\n")))
+	assert.Contains("\nS\n", content)
+	assert.Contains(" Tj ET", content)
+
+	// the legend text can be mapped back to characters, including ones outside latin-1.
+	assert.Contains("<03c3>", string(document))
+	assert.Contains("/FontFile2 8 0 R", string(document))
+	assert.Contains("/Length1", string(document))
+}
+
+func TestPDFRendererPaint(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PDF(100, 100)
+	assert.Nil(err)
+	pr := r.(*pdfRenderer)
+
+	r.SetFillColor(chart.ColorBlue.WithAlpha(64))
+	r.SetStrokeColor(chart.ColorRed)
+	r.SetStrokeWidth(2)
+	r.SetStrokeDashArray([]float64{5, 5})
+	r.MoveTo(0, 0)
+	r.LineTo(10, 10)
+	r.Close()
+	r.FillStroke()
+	content := pr.content.String()
+	assert.Contains("[5 5] 0 d\n", content)
+	assert.Contains("/GS1 gs\n0 0 m\n10 10 l\nh\nB\nQ\n", content)
+	assert.Equal(map[[2]uint8]string{{255, 64}: "GS1"}, pr.alphas)
+
+	// transparent colors, zero widths and empty paths aren't drawn.
+	pr.content.Reset()
+	r.SetStrokeColor(chart.ColorTransparent)
+	r.MoveTo(0, 0)
+	r.LineTo(10, 10)
+	r.Stroke()
+	r.SetStrokeColor(chart.ColorRed)
+	r.SetStrokeWidth(0)
+	r.MoveTo(0, 0)
+	r.LineTo(10, 10)
+	r.Stroke()
+	r.SetStrokeWidth(1)
+	r.Stroke()
+	assert.Empty(pr.content.String())
+}
+
+func TestPDFRendererTextRotation(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := PDF(100, 100)
+	assert.Nil(err)
+	pr := r.(*pdfRenderer)
+
+	r.SetFontColor(chart.ColorBlack)
+	r.SetFontSize(10)
+	r.SetTextRotation(math.Pi / 2)
+	r.Text("A", 10, 20)
+	assert.Contains("Tf 0 1 1 0 10 20 Tm <", pr.content.String())
+	assert.Equal(pr.MeasureText("A").Width(), int(pr.getFontSizePixels()))
+}
+
+func TestPDFNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("1", pdfNumber(1))
+	assert.Equal("0.75", pdfNumber(0.75))
+	assert.Equal("0.333", pdfNumber(1.0/3.0))
+	assert.Equal("1000000", pdfNumber(1e6))
+	assert.Equal("0", pdfNumber(-0.0001))
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wcharczuk/go-chart"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+// Period is a shaded span of time.
+type Period struct {
+	Start time.Time
+	End   time.Time
+	Label string
+}
+
+// ParseDateRange parses a period as `start..end` or `start..end:label` with dates formatted `2006-01-02`
+// (i.e. `2017-03-01..2017-03-15:Blackout`). Both days are included, in the given location.
+func ParseDateRange(value string, loc *time.Location) (Period, error) {
+	dates, label := splitLabel(value)
+	parts := strings.Split(dates, "..")
+	if len(parts) != 2 {
+		return Period{}, fmt.Errorf("invalid date range: %s", value)
+	}
+	start, startErr := time.ParseInLocation(chart.DefaultDateFormat, strings.TrimSpace(parts[0]), loc)
+	end, endErr := time.ParseInLocation(chart.DefaultDateFormat, strings.TrimSpace(parts[1]), loc)
+	if startErr != nil || endErr != nil || end.Before(start) {
+		return Period{}, fmt.Errorf("invalid date range: %s", value)
+	}
+	return Period{Start: start, End: end.AddDate(0, 0, 1), Label: label}, nil
+}
+
+// PeriodSeries shades periods across the full height of the canvas, labeled at the top left of each period.
+// Periods are clipped to the canvas, and don't change the chart's time range.
+type PeriodSeries struct {
+	Name    string
+	Style   chart.Style
+	YAxis   chart.YAxisType
+	Periods []Period
+}
+
+// GetName implements chart.Series.
+func (ps PeriodSeries) GetName() string {
+	return ps.Name
+}
+
+// GetStyle implements chart.Series.
+func (ps PeriodSeries) GetStyle() chart.Style {
+	return ps.Style
+}
+
+// GetYAxis implements chart.Series.
+func (ps PeriodSeries) GetYAxis() chart.YAxisType {
+	return ps.YAxis
+}
+
+// Render implements chart.Series.
+func (ps PeriodSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	style := ps.Style.InheritFrom(defaults)
+	if style.FontColor.IsZero() {
+		style.FontColor = chart.DefaultTextColor
+	}
+	for _, period := range ps.Periods {
+		left := util.Math.MaxInt(canvasBox.Left+xrange.Translate(util.Time.ToFloat64(period.Start)), canvasBox.Left)
+		right := util.Math.MinInt(canvasBox.Left+xrange.Translate(util.Time.ToFloat64(period.End)), canvasBox.Right)
+		if right <= left {
+			continue
+		}
+
+		chart.Draw.Box(r, chart.Box{Top: canvasBox.Top, Left: left, Right: right, Bottom: canvasBox.Bottom}, chart.Style{
+			FillColor:   style.FillColor,
+			StrokeColor: style.FillColor,
+			StrokeWidth: 1,
+		})
+		if len(period.Label) > 0 {
+			style.GetTextOptions().WriteToRenderer(r)
+			r.Text(period.Label, left+DefaultLabelPadding, canvasBox.Top+DefaultLabelPadding+r.MeasureText(period.Label).Height())
+		}
+	}
+}
+
+// Validate implements chart.Series.
+func (ps PeriodSeries) Validate() error {
+	for _, period := range ps.Periods {
+		if period.End.Before(period.Start) {
+			return fmt.Errorf("period end must not be before its start")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestParseDateRange(t *testing.T) {
+	assert := assert.New(t)
+
+	period, err := ParseDateRange("2017-03-01..2017-03-15:Blackout", time.UTC)
+	assert.Nil(err)
+	assert.Equal(time.Date(2017, 03, 01, 0, 0, 0, 0, time.UTC), period.Start)
+	assert.Equal(time.Date(2017, 03, 16, 0, 0, 0, 0, time.UTC), period.End, "the end day is included")
+	assert.Equal("Blackout", period.Label)
+
+	_, err = ParseDateRange("2017-03-15..2017-03-01", time.UTC)
+	assert.NotNil(err)
+	_, err = ParseDateRange("2017-03-01", time.UTC)
+	assert.NotNil(err)
+	_, err = ParseDateRange("03/01/2017..03/15/2017", time.UTC)
+	assert.NotNil(err)
+}
+
+func TestPeriodSeriesRender(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2017, 03, 01, 12, 0, 0, 0, time.UTC)
+	prices := chart.TimeSeries{
+		Style:   chart.StyleShow(),
+		XValues: []time.Time{start, start.AddDate(0, 0, 10)},
+		YValues: []float64{10, 11},
+	}
+	periods := PeriodSeries{
+		Style: chart.Style{Show: true, FillColor: chart.ColorBlack.WithAlpha(24)},
+		Periods: []Period{
+			{Start: start.AddDate(0, 0, 2), End: start.AddDate(0, 0, 4), Label: "Blackout"},
+			{Start: start.AddDate(0, 0, 20), End: start.AddDate(0, 0, 30), Label: "Later"},
+		},
+	}
+	assert.Nil(periods.Validate())
+
+	graph := chart.Chart{Width: 400, Height: 200, Series: []chart.Series{periods, prices}}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(chart.SVG, buffer))
+	assert.Contains(">Blackout</text>", buffer.String())
+	assert.False(strings.Contains(buffer.String(), "Later"), "periods outside the x range are skipped")
+
+	assert.NotNil(PeriodSeries{Periods: []Period{{Start: start, End: start.AddDate(0, 0, -1)}}}.Validate())
+}
@@ -0,0 +1,48 @@
+package core
+
+import "github.com/wcharczuk/go-chart"
+
+// Positions are where a chart placed x values, in pixels of the chart's image.
+type Positions struct {
+	Canvas chart.Box
+	X      []int
+}
+
+// PositionSeries draws nothing; as the chart renders it records where its x values fall on the canvas into
+// `Positions`, so interactive elements can be laid over the image.
+type PositionSeries struct {
+	XValues   []float64
+	Positions *Positions
+}
+
+// GetName implements chart.Series.
+func (ps PositionSeries) GetName() string {
+	return ""
+}
+
+// GetStyle implements chart.Series.
+func (ps PositionSeries) GetStyle() chart.Style {
+	return chart.StyleShow()
+}
+
+// GetYAxis implements chart.Series.
+func (ps PositionSeries) GetYAxis() chart.YAxisType {
+	return chart.YAxisPrimary
+}
+
+// Render implements chart.Series.
+func (ps PositionSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	if ps.Positions == nil {
+		return
+	}
+	ps.Positions.Canvas = canvasBox
+	ps.Positions.X = make([]int, len(ps.XValues))
+	for index, x := range ps.XValues {
+		ps.Positions.X[index] = canvasBox.Left + xrange.Translate(x)
+	}
+}
+
+// Validate implements chart.Series.
+func (ps PositionSeries) Validate() error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RenderCacheEntry is a rendered response and the validators it's served with.
+type RenderCacheEntry struct {
+	ContentType string
+	Body        []byte
+	// ETag and LastModified change when the data the response was rendered from does.
+	ETag         string
+	LastModified time.Time
+	// Expires is when the entry has to be revalidated against the data.
+	Expires time.Time
+}
+
+// IsFresh returns if the entry can be served without revalidating it.
+func (rce *RenderCacheEntry) IsFresh(now time.Time) bool {
+	return now.Before(rce.Expires)
+}
+
+// MaxAge returns the seconds the entry stays fresh for, i.e. for `Cache-Control`.
+func (rce *RenderCacheEntry) MaxAge(now time.Time) int {
+	if !rce.IsFresh(now) {
+		return 0
+	}
+	return int(rce.Expires.Sub(now) / time.Second)
+}
+
+// NewRenderCache returns a render cache that holds at most `maxBytes` of responses, each at most `maxEntryBytes`.
+func NewRenderCache(maxBytes, maxEntryBytes int) *RenderCache {
+	return &RenderCache{maxBytes: maxBytes, maxEntryBytes: maxEntryBytes, entries: map[string]*RenderCacheEntry{}}
+}
+
+// RenderCache holds rendered responses by key. Entries are kept past their expiry so a response whose data
+// hasn't changed can be revalidated (and served again) without rendering it again; when the cache is full the
+// expired entries, then the entries closest to expiring, are evicted.
+type RenderCache struct {
+	sync.Mutex
+	maxBytes      int
+	maxEntryBytes int
+	bytes         int
+	entries       map[string]*RenderCacheEntry
+}
+
+// Get returns the entry for a key, fresh or not.
+func (rc *RenderCache) Get(key string) (*RenderCacheEntry, bool) {
+	rc.Lock()
+	defer rc.Unlock()
+	entry, ok := rc.entries[key]
+	return entry, ok
+}
+
+// Set adds or replaces the entry for a key; entries larger than the cache's entry size aren't cached.
+func (rc *RenderCache) Set(key string, entry *RenderCacheEntry) {
+	rc.Lock()
+	defer rc.Unlock()
+	rc.remove(key)
+	if len(entry.Body) > rc.maxEntryBytes || len(entry.Body) > rc.maxBytes {
+		return
+	}
+	rc.evict(time.Now(), len(entry.Body))
+	rc.entries[key] = entry
+	rc.bytes += len(entry.Body)
+}
+
+// Len returns the number of entries.
+func (rc *RenderCache) Len() int {
+	rc.Lock()
+	defer rc.Unlock()
+	return len(rc.entries)
+}
+
+// Bytes returns the total size of the entries' bodies.
+func (rc *RenderCache) Bytes() int {
+	rc.Lock()
+	defer rc.Unlock()
+	return rc.bytes
+}
+
+// remove removes the entry for a key; it must be called with the lock held.
+func (rc *RenderCache) remove(key string) {
+	if entry, ok := rc.entries[key]; ok {
+		rc.bytes -= len(entry.Body)
+		delete(rc.entries, key)
+	}
+}
+
+// evict makes room for an entry of `size` bytes; it must be called with the lock held.
+func (rc *RenderCache) evict(now time.Time, size int) {
+	if rc.bytes+size <= rc.maxBytes {
+		return
+	}
+	for key, entry := range rc.entries {
+		if !entry.IsFresh(now) {
+			rc.remove(key)
+		}
+	}
+	for rc.bytes+size > rc.maxBytes {
+		var oldestKey string
+		var oldest *RenderCacheEntry
+		for key, entry := range rc.entries {
+			if oldest == nil || entry.Expires.Before(oldest.Expires) {
+				oldestKey, oldest = key, entry
+			}
+		}
+		rc.remove(oldestKey)
+	}
+}
+
+// ETag returns a strong entity tag for the parts, i.e. the parameters and version of the data a response is
+// rendered from.
+func ETag(parts ...string) string {
+	hash := sha1.Sum([]byte(strings.Join(parts, "\n")))
+	return `"` + hex.EncodeToString(hash[:]) + `"`
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestRenderCacheEntryMaxAge(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2017, 05, 01, 12, 0, 0, 0, time.UTC)
+	entry := &RenderCacheEntry{Expires: now.Add(90 * time.Second)}
+	assert.True(entry.IsFresh(now))
+	assert.Equal(90, entry.MaxAge(now))
+	assert.False(entry.IsFresh(now.Add(2 * time.Minute)))
+	assert.Zero(entry.MaxAge(now.Add(2 * time.Minute)))
+}
+
+func TestRenderCacheEvict(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	body := make([]byte, 10)
+	cache := NewRenderCache(20, 15)
+	cache.Set("expired", &RenderCacheEntry{Body: body, Expires: now.Add(-time.Minute)})
+	cache.Set("soon", &RenderCacheEntry{Body: body, Expires: now.Add(time.Minute)})
+	cache.Set("later", &RenderCacheEntry{Body: body, Expires: now.Add(time.Hour)})
+	assert.Equal(2, cache.Len())
+	assert.Equal(20, cache.Bytes())
+	_, ok := cache.Get("expired")
+	assert.False(ok, "expired entries are evicted first")
+
+	cache.Set("latest", &RenderCacheEntry{Body: body, Expires: now.Add(2 * time.Hour)})
+	assert.Equal(2, cache.Len())
+	_, ok = cache.Get("soon")
+	assert.False(ok, "then the entries closest to expiring")
+	_, ok = cache.Get("later")
+	assert.True(ok)
+
+	cache.Set("later", &RenderCacheEntry{Body: make([]byte, 5), Expires: now.Add(3 * time.Hour)})
+	assert.Equal(2, cache.Len(), "replacing an entry doesn't evict another")
+	assert.Equal(15, cache.Bytes())
+	_, ok = cache.Get("latest")
+	assert.True(ok)
+
+	cache.Set("later", &RenderCacheEntry{Body: make([]byte, 16), Expires: now.Add(3 * time.Hour)})
+	_, ok = cache.Get("later")
+	assert.False(ok, "entries larger than the entry size aren't cached, and replace what was")
+	assert.Equal(1, cache.Len())
+	assert.Equal(10, cache.Bytes())
+}
+
+func TestETag(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(ETag("a", "b"), ETag("a", "b"))
+	assert.NotEqual(ETag("a", "b"), ETag("a", "c"))
+	assert.NotEqual(ETag("a\nb"), ETag("a", "c"))
+	assert.True(len(ETag("a")) == 42 && ETag("a")[0] == '"')
+}
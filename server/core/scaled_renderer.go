@@ -0,0 +1,112 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+// MaxScaledPixels is the most pixels a scaled image can have.
+const MaxScaledPixels = 8192 * 8192
+
+// Scaled draws a renderable at a multiple of its size, for high density (i.e. retina) displays. The renderable
+// is laid out at its own size and every coordinate, stroke and font is scaled as it's drawn, so a 1024x400 chart
+// at a scale of 2 is a 2048x800 image that looks the same, only sharper.
+type Scaled struct {
+	Renderable Renderable
+	Scale      float64
+}
+
+// Render implements Renderable.
+func (s Scaled) Render(rp chart.RendererProvider, w io.Writer) error {
+	return s.Renderable.Render(func(width, height int) (chart.Renderer, error) {
+		scaledWidth, scaledHeight := scaleInt(width, s.Scale), scaleInt(height, s.Scale)
+		if float64(scaledWidth)*float64(scaledHeight) > MaxScaledPixels {
+			return nil, fmt.Errorf("a %dx%d image is too large to draw", scaledWidth, scaledHeight)
+		}
+		r, err := rp(scaledWidth, scaledHeight)
+		if err != nil {
+			return nil, err
+		}
+		sr := &scaledRenderer{Renderer: r, scale: s.Scale}
+		sr.SetDPI(chart.DefaultDPI)
+		return sr, nil
+	}, w)
+}
+
+// scaledRenderer scales all drawing by a fixed factor; fonts are scaled through the dpi.
+type scaledRenderer struct {
+	chart.Renderer
+	scale float64
+	dpi   float64
+}
+
+func (sr *scaledRenderer) GetDPI() float64 {
+	return sr.dpi
+}
+
+func (sr *scaledRenderer) SetDPI(dpi float64) {
+	sr.dpi = dpi
+	sr.Renderer.SetDPI(dpi * sr.scale)
+}
+
+func (sr *scaledRenderer) SetStrokeWidth(width float64) {
+	sr.Renderer.SetStrokeWidth(width * sr.scale)
+}
+
+func (sr *scaledRenderer) SetStrokeDashArray(dashArray []float64) {
+	scaled := make([]float64, len(dashArray))
+	for index, dash := range dashArray {
+		scaled[index] = dash * sr.scale
+	}
+	sr.Renderer.SetStrokeDashArray(scaled)
+}
+
+func (sr *scaledRenderer) MoveTo(x, y int) {
+	sr.Renderer.MoveTo(sr.scaleInt(x), sr.scaleInt(y))
+}
+
+func (sr *scaledRenderer) LineTo(x, y int) {
+	sr.Renderer.LineTo(sr.scaleInt(x), sr.scaleInt(y))
+}
+
+func (sr *scaledRenderer) QuadCurveTo(cx, cy, x, y int) {
+	sr.Renderer.QuadCurveTo(sr.scaleInt(cx), sr.scaleInt(cy), sr.scaleInt(x), sr.scaleInt(y))
+}
+
+func (sr *scaledRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	sr.Renderer.ArcTo(sr.scaleInt(cx), sr.scaleInt(cy), rx*sr.scale, ry*sr.scale, startAngle, delta)
+}
+
+func (sr *scaledRenderer) Circle(radius float64, x, y int) {
+	sr.Renderer.Circle(radius*sr.scale, sr.scaleInt(x), sr.scaleInt(y))
+}
+
+func (sr *scaledRenderer) Text(body string, x, y int) {
+	sr.Renderer.Text(body, sr.scaleInt(x), sr.scaleInt(y))
+}
+
+// MeasureText measures the text as it's drawn, then scales it back to the size the chart is laid out at.
+func (sr *scaledRenderer) MeasureText(body string) chart.Box {
+	box := sr.Renderer.MeasureText(body)
+	return chart.Box{
+		Top:    unscaleInt(box.Top, sr.scale),
+		Left:   unscaleInt(box.Left, sr.scale),
+		Right:  unscaleInt(box.Right, sr.scale),
+		Bottom: unscaleInt(box.Bottom, sr.scale),
+	}
+}
+
+func (sr *scaledRenderer) scaleInt(value int) int {
+	return scaleInt(value, sr.scale)
+}
+
+func scaleInt(value int, scale float64) int {
+	return int(math.Floor(float64(value)*scale + 0.5))
+}
+
+func unscaleInt(value int, scale float64) int {
+	return int(math.Floor(float64(value)/scale + 0.5))
+}
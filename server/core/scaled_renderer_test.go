@@ -0,0 +1,64 @@
+package core
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestScaledRender(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := chart.Chart{
+		Width:  200,
+		Height: 100,
+		Series: []chart.Series{
+			chart.ContinuousSeries{XValues: []float64{0, 1, 2}, YValues: []float64{1, 3, 2}},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(Scaled{Renderable: graph, Scale: 2}.Render(chart.PNG, buffer))
+	decoded, err := png.Decode(buffer)
+	assert.Nil(err)
+	assert.Equal(400, decoded.Bounds().Dx())
+	assert.Equal(200, decoded.Bounds().Dy())
+}
+
+func TestScaledRendererMeasureText(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := chart.PNG(200, 100)
+	assert.Nil(err)
+	r.SetDPI(chart.DefaultDPI)
+	r.SetFont(chart.StyleTextDefaults().GetFont())
+	r.SetFontSize(10)
+	unscaled := r.MeasureText("100.00")
+
+	sr, err := chart.PNG(400, 200)
+	assert.Nil(err)
+	scaled := &scaledRenderer{Renderer: sr, scale: 2}
+	scaled.SetDPI(chart.DefaultDPI)
+	scaled.SetFont(chart.StyleTextDefaults().GetFont())
+	scaled.SetFontSize(10)
+	assert.Equal(chart.DefaultDPI, scaled.GetDPI())
+	measured := scaled.MeasureText("100.00")
+	assert.InDelta(float64(unscaled.Width()), float64(measured.Width()), 1, "text measures the same as it does unscaled")
+	assert.InDelta(float64(unscaled.Height()), float64(measured.Height()), 1)
+}
+
+func TestScaledRenderTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := chart.Chart{
+		Width:  4096,
+		Height: 4096,
+		Series: []chart.Series{
+			chart.ContinuousSeries{XValues: []float64{0, 1}, YValues: []float64{1, 2}},
+		},
+	}
+	assert.NotNil(Scaled{Renderable: graph, Scale: 4}.Render(chart.PNG, bytes.NewBuffer(nil)))
+}
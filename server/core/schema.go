@@ -0,0 +1,162 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// SchemaTypeObject is a json object.
+	SchemaTypeObject = "object"
+	// SchemaTypeArray is a json array.
+	SchemaTypeArray = "array"
+	// SchemaTypeString is a json string.
+	SchemaTypeString = "string"
+	// SchemaTypeNumber is any json number.
+	SchemaTypeNumber = "number"
+	// SchemaTypeInteger is a json number without a fractional part.
+	SchemaTypeInteger = "integer"
+	// SchemaTypeBoolean is a json boolean.
+	SchemaTypeBoolean = "boolean"
+)
+
+// Schema is the subset of JSON Schema needed to validate request documents. It marshals
+// to standard JSON Schema so it can be published for clients.
+//
+// Objects are closed: properties that aren't listed are rejected.
+type Schema struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	MaxItems    int                `json:"maxItems,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+}
+
+// ObjectSchema returns a closed object schema with the given properties.
+func ObjectSchema(description string, properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: SchemaTypeObject, Description: description, Properties: properties, Required: required}
+}
+
+// ArraySchema returns an array schema of up to maxItems items (or any number if maxItems is 0).
+func ArraySchema(description string, items *Schema, maxItems int) *Schema {
+	return &Schema{Type: SchemaTypeArray, Description: description, Items: items, MaxItems: maxItems}
+}
+
+// StringSchema returns a string schema, optionally restricted to a set of values.
+func StringSchema(description string, enum ...string) *Schema {
+	return &Schema{Type: SchemaTypeString, Description: description, Enum: enum}
+}
+
+// NumberSchema returns a number schema within an inclusive range.
+func NumberSchema(description string, minimum, maximum float64) *Schema {
+	return &Schema{Type: SchemaTypeNumber, Description: description, Minimum: &minimum, Maximum: &maximum}
+}
+
+// IntegerSchema returns an integer schema within an inclusive range.
+func IntegerSchema(description string, minimum, maximum int) *Schema {
+	min, max := float64(minimum), float64(maximum)
+	return &Schema{Type: SchemaTypeInteger, Description: description, Minimum: &min, Maximum: &max}
+}
+
+// BooleanSchema returns a boolean schema.
+func BooleanSchema(description string) *Schema {
+	return &Schema{Type: SchemaTypeBoolean, Description: description}
+}
+
+// Validate returns an error naming the first path in a decoded json value (as produced by
+// `json.Unmarshal` into an `interface{}`) that doesn't match the schema.
+func (s *Schema) Validate(value interface{}) error {
+	return s.validate("$", value)
+}
+
+func (s *Schema) validate(path string, value interface{}) error {
+	switch s.Type {
+	case SchemaTypeObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return schemaTypeError(path, s.Type)
+		}
+		for _, key := range s.Required {
+			if _, hasKey := object[key]; !hasKey {
+				return fmt.Errorf("%s.%s is required", path, key)
+			}
+		}
+		// visit the keys in order so the reported error is stable.
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			property, hasProperty := s.Properties[key]
+			if !hasProperty {
+				return fmt.Errorf("%s.%s is not a known property", path, key)
+			}
+			if err := property.validate(path+"."+key, object[key]); err != nil {
+				return err
+			}
+		}
+	case SchemaTypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return schemaTypeError(path, s.Type)
+		}
+		if s.MaxItems > 0 && len(items) > s.MaxItems {
+			return fmt.Errorf("%s cannot have more than %d items", path, s.MaxItems)
+		}
+		if s.Items != nil {
+			for index, item := range items {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, index), item); err != nil {
+					return err
+				}
+			}
+		}
+	case SchemaTypeString:
+		text, ok := value.(string)
+		if !ok {
+			return schemaTypeError(path, s.Type)
+		}
+		if len(s.Enum) > 0 && !containsString(s.Enum, text) {
+			return fmt.Errorf("%s must be one of: %s", path, strings.Join(s.Enum, ", "))
+		}
+	case SchemaTypeNumber, SchemaTypeInteger:
+		number, ok := value.(float64)
+		if !ok || (s.Type == SchemaTypeInteger && number != float64(int64(number))) {
+			return schemaTypeError(path, s.Type)
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			return fmt.Errorf("%s must be at least %g", path, *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			return fmt.Errorf("%s must be at most %g", path, *s.Maximum)
+		}
+	case SchemaTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return schemaTypeError(path, s.Type)
+		}
+	default:
+		return fmt.Errorf("%s has an invalid schema type: %s", path, s.Type)
+	}
+	return nil
+}
+
+func schemaTypeError(path, schemaType string) error {
+	if schemaType == SchemaTypeArray || schemaType == SchemaTypeObject || schemaType == SchemaTypeInteger {
+		return fmt.Errorf("%s must be an %s", path, schemaType)
+	}
+	return fmt.Errorf("%s must be a %s", path, schemaType)
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func testSchema() *Schema {
+	return ObjectSchema("a test document", map[string]*Schema{
+		"name":  StringSchema("the name"),
+		"kind":  StringSchema("the kind", "a", "b"),
+		"count": IntegerSchema("the count", 1, 10),
+		"ratio": NumberSchema("the ratio", 0, 1),
+		"on":    BooleanSchema("is on"),
+		"tags":  ArraySchema("the tags", StringSchema("a tag"), 2),
+		"child": ObjectSchema("a child", map[string]*Schema{
+			"value": NumberSchema("the value", -100, 100),
+		}, "value"),
+	}, "name")
+}
+
+func validateJSON(schema *Schema, document string) error {
+	var value interface{}
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		return err
+	}
+	return schema.Validate(value)
+}
+
+func TestSchemaValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	schema := testSchema()
+	assert.Nil(validateJSON(schema, `{"name":"test"}`))
+	assert.Nil(validateJSON(schema, `{"name":"test","kind":"b","count":10,"ratio":0.5,"on":true,"tags":["x","y"],"child":{"value":-1.5}}`))
+}
+
+func TestSchemaValidateErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	schema := testSchema()
+	testCases := map[string]string{
+		`[]`:                                     "$ must be an object",
+		`{}`:                                     "$.name is required",
+		`{"name":"test","other":1}`:              "$.other is not a known property",
+		`{"name":1}`:                             "$.name must be a string",
+		`{"name":"test","kind":"c"}`:             "$.kind must be one of: a, b",
+		`{"name":"test","count":1.5}`:            "$.count must be an integer",
+		`{"name":"test","count":11}`:             "$.count must be at most 10",
+		`{"name":"test","ratio":-0.5}`:           "$.ratio must be at least 0",
+		`{"name":"test","on":"true"}`:            "$.on must be a boolean",
+		`{"name":"test","tags":["x","y","z"]}`:   "$.tags cannot have more than 2 items",
+		`{"name":"test","tags":["x",2]}`:         "$.tags[1] must be a string",
+		`{"name":"test","child":{}}`:             "$.child.value is required",
+		`{"name":"test","child":{"value":"1"}}`:  "$.child.value must be a number",
+		`{"name":"test","child":{"value":1000}}`: "$.child.value must be at most 100",
+	}
+	for document, expected := range testCases {
+		err := validateJSON(schema, document)
+		assert.NotNil(err, document)
+		if err != nil {
+			assert.Equal(expected, err.Error(), document)
+		}
+	}
+}
+
+func TestSchemaMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	contents, err := json.Marshal(ObjectSchema("a test document", map[string]*Schema{
+		"count": IntegerSchema("the count", 1, 10),
+	}, "count"))
+	assert.Nil(err)
+	assert.Equal(`{"type":"object","description":"a test document","properties":{"count":{"type":"integer","description":"the count","minimum":1,"maximum":10}},"required":["count"]}`, string(contents))
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+// SeriesTable is the values of a set of series aligned by their x values, one row per x value and one
+// column per series; it is the data behind a chart.
+type SeriesTable struct {
+	Columns []string         `json:"columns"`
+	Rows    []SeriesTableRow `json:"rows"`
+}
+
+// SeriesTableRow is the values of each series at an x value; a nil value is a series that has no value at x.
+// `X` is the formatted x value.
+type SeriesTableRow struct {
+	X      string
+	XValue float64
+	Values []*float64
+}
+
+// MarshalJSON implements json.Marshaler, writing the row as an array that lines up with the columns.
+func (str SeriesTableRow) MarshalJSON() ([]byte, error) {
+	values := make([]interface{}, len(str.Values)+1)
+	values[0] = str.X
+	for index, value := range str.Values {
+		if value != nil {
+			values[index+1] = *value
+		}
+	}
+	return json.Marshal(values)
+}
+
+// NewSeriesTable returns the values of the series aligned by x value, formatting the x values with `xvf`.
+// Series that aren't shown or don't provide values (i.e. annotations) are skipped. Series with bounded values,
+// like bollinger bands or candles, have a column for each bound, named for the series with a " High" and " Low" suffix.
+func NewSeriesTable(xname string, xvf chart.ValueFormatter, series ...chart.Series) SeriesTable {
+	table := SeriesTable{Columns: []string{xname}}
+	valuesByX := map[float64][]*float64{}
+	set := func(column int, x, y float64) {
+		if math.IsNaN(y) || math.IsInf(y, 0) {
+			return
+		}
+		values, ok := valuesByX[x]
+		if !ok {
+			values = make([]*float64, column+1)
+		}
+		for len(values) <= column {
+			values = append(values, nil)
+		}
+		values[column] = &y
+		valuesByX[x] = values
+	}
+
+	for _, s := range series {
+		if !s.GetStyle().Show {
+			continue
+		}
+		column := len(table.Columns) - 1
+		if typed, isValuesProvider := s.(chart.ValuesProvider); isValuesProvider {
+			table.Columns = append(table.Columns, s.GetName())
+			for index := 0; index < typed.Len(); index++ {
+				x, y := typed.GetValues(index)
+				set(column, x, y)
+			}
+		} else if typed, isBoundedValuesProvider := s.(chart.BoundedValuesProvider); isBoundedValuesProvider {
+			table.Columns = append(table.Columns, s.GetName()+" High", s.GetName()+" Low")
+			for index := 0; index < typed.Len(); index++ {
+				x, y0, y1 := typed.GetBoundedValues(index)
+				set(column, x, math.Max(y0, y1))
+				set(column+1, x, math.Min(y0, y1))
+			}
+		}
+	}
+
+	xvalues := make([]float64, 0, len(valuesByX))
+	for x := range valuesByX {
+		xvalues = append(xvalues, x)
+	}
+	sort.Float64s(xvalues)
+
+	for _, x := range xvalues {
+		values := valuesByX[x]
+		for len(values) < len(table.Columns)-1 {
+			values = append(values, nil)
+		}
+		table.Rows = append(table.Rows, SeriesTableRow{X: xvf(x), XValue: x, Values: values})
+	}
+	return table
+}
+
+// WriteCSV writes the table as csv with a header row; missing values are left empty.
+func (st SeriesTable) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(st.Columns); err != nil {
+		return err
+	}
+	for _, row := range st.Rows {
+		record := make([]string, len(row.Values)+1)
+		record[0] = row.X
+		for index, value := range row.Values {
+			if value != nil {
+				record[index+1] = strconv.FormatFloat(*value, 'f', -1, 64)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the table as a json object of its columns and rows; missing values are null.
+func (st SeriesTable) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(st)
+}
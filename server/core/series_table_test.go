@@ -0,0 +1,53 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestNewSeriesTable(t *testing.T) {
+	assert := assert.New(t)
+
+	price := chart.ContinuousSeries{
+		Name:    "TEST",
+		Style:   chart.StyleShow(),
+		XValues: []float64{1, 2, 3},
+		YValues: []float64{10, 12, 11},
+	}
+	sma := &chart.SMASeries{Name: "TEST - SMA", Style: chart.StyleShow(), InnerSeries: price, Period: 2}
+	hidden := chart.ContinuousSeries{Name: "Hidden", XValues: []float64{1}, YValues: []float64{1}}
+	annotation := chart.AnnotationSeries{Name: "Last", Style: chart.StyleShow(), Annotations: []chart.Value2{{XValue: 3, YValue: 11}}}
+	bands := &chart.BollingerBandsSeries{Name: "TEST BB", Style: chart.StyleShow(), InnerSeries: chart.ContinuousSeries{XValues: []float64{2, 4}, YValues: []float64{1, 3}}, Period: 2, K: 1}
+
+	table := NewSeriesTable("x", chart.FloatValueFormatter, price, sma, hidden, annotation, bands)
+	assert.Equal([]string{"x", "TEST", "TEST - SMA", "TEST BB High", "TEST BB Low"}, table.Columns)
+	assert.Len(table.Rows, 4)
+	assert.Equal("1.00", table.Rows[0].X)
+	assert.Equal(10.0, *table.Rows[0].Values[0])
+	assert.Equal(11.0, *table.Rows[1].Values[1])
+	assert.Nil(table.Rows[0].Values[2], "series without a value at x are nil")
+	assert.Equal(3.0, *table.Rows[3].Values[2], "the upper band is the high column")
+	assert.Equal(1.0, *table.Rows[3].Values[3])
+	assert.Nil(table.Rows[3].Values[0])
+}
+
+func TestSeriesTableWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	table := NewSeriesTable("x", func(v interface{}) string { return fmt.Sprintf("%v", v) },
+		chart.ContinuousSeries{Name: "A", Style: chart.StyleShow(), XValues: []float64{1, 2}, YValues: []float64{0.5, 1}},
+		chart.ContinuousSeries{Name: "B, C", Style: chart.StyleShow(), XValues: []float64{2}, YValues: []float64{3}},
+	)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(table.WriteCSV(buffer))
+	assert.Equal("x,A,\"B, C\"\n1,0.5,\n2,1,3\n", buffer.String())
+
+	buffer.Reset()
+	assert.Nil(table.WriteJSON(buffer))
+	assert.Equal(`{"columns":["x","A","B, C"],"rows":[["1",0.5,null],["2",1,3]]}`+"\n", buffer.String())
+}
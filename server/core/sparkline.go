@@ -0,0 +1,131 @@
+package core
+
+import (
+	"io"
+	"math"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+const (
+	// DefaultSparklinePadding is the gap (in pixels) between the edge of a sparkline and its line or label.
+	DefaultSparklinePadding = 2
+	// DefaultSparklineFontSize is the default size of a sparkline's label.
+	DefaultSparklineFontSize = 9.0
+)
+
+// Sparkline is a tiny line chart with no axes, legend or annotations; just the line, an optional fill
+// beneath it and an optional label (i.e. the last price) to its right.
+// Values are spaced evenly regardless of time, so overnight and weekend gaps are collapsed.
+type Sparkline struct {
+	Width  int
+	Height int
+	Values []float64
+	Label  string
+
+	// Style sets the line (stroke), the area beneath it (fill) and the label (font).
+	Style chart.Style
+	// Background sets the fill behind the sparkline; it defaults to white.
+	Background chart.Style
+}
+
+// Render implements Renderable.
+func (s Sparkline) Render(rp chart.RendererProvider, w io.Writer) error {
+	r, err := rp(s.Width, s.Height)
+	if err != nil {
+		return err
+	}
+
+	background := s.Background
+	if background.FillColor.IsZero() {
+		background.FillColor = drawing.ColorWhite
+	}
+	chart.Draw.Box(r, chart.Box{Right: s.Width, Bottom: s.Height}, background)
+
+	canvas := chart.Box{
+		Top:    DefaultSparklinePadding,
+		Left:   DefaultSparklinePadding,
+		Right:  s.Width - DefaultSparklinePadding,
+		Bottom: s.Height - DefaultSparklinePadding,
+	}
+	style, err := s.getStyle()
+	if err != nil {
+		return err
+	}
+	if len(s.Label) > 0 {
+		style.GetTextOptions().WriteToRenderer(r)
+		tb := r.MeasureText(s.Label)
+		r.Text(s.Label, s.Width-DefaultSparklinePadding-tb.Width(), (s.Height+tb.Height())>>1)
+		canvas.Right -= tb.Width() + DefaultSparklinePadding
+	}
+	s.renderLine(r, canvas, style)
+	return r.Save(w)
+}
+
+// getStyle returns the style with defaults for the line and label; the label defaults to the line color.
+func (s Sparkline) getStyle() (chart.Style, error) {
+	style := s.Style
+	if style.StrokeColor.IsZero() {
+		style.StrokeColor = chart.ColorBlue
+	}
+	if style.StrokeWidth == 0 {
+		style.StrokeWidth = 1
+	}
+	if style.Font == nil {
+		font, err := chart.GetDefaultFont()
+		if err != nil {
+			return style, err
+		}
+		style.Font = font
+	}
+	if style.FontSize == 0 {
+		style.FontSize = DefaultSparklineFontSize
+	}
+	if style.FontColor.IsZero() {
+		style.FontColor = style.StrokeColor
+	}
+	return style, nil
+}
+
+// renderLine draws the values as a line scaled to fill the canvas, with the fill beneath it.
+func (s Sparkline) renderLine(r chart.Renderer, canvas chart.Box, style chart.Style) {
+	if len(s.Values) == 0 || canvas.Width() <= 0 {
+		return
+	}
+
+	min, max := math.MaxFloat64, -math.MaxFloat64
+	for _, v := range s.Values {
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+
+	xs, ys := make([]int, len(s.Values)), make([]int, len(s.Values))
+	for index, v := range s.Values {
+		xs[index] = canvas.Left
+		if len(s.Values) > 1 {
+			xs[index] += int(float64(index*canvas.Width())/float64(len(s.Values)-1) + 0.5)
+		}
+		ys[index] = canvas.Top + (canvas.Height() >> 1)
+		if max > min {
+			ys[index] = canvas.Bottom - int((v-min)/(max-min)*float64(canvas.Height())+0.5)
+		}
+	}
+
+	if !style.FillColor.IsZero() {
+		style.GetFillOptions().WriteToRenderer(r)
+		r.MoveTo(xs[0], canvas.Bottom)
+		for index := range xs {
+			r.LineTo(xs[index], ys[index])
+		}
+		r.LineTo(xs[len(xs)-1], canvas.Bottom)
+		r.Close()
+		r.Fill()
+	}
+
+	style.GetStrokeOptions().WriteToRenderer(r)
+	r.MoveTo(xs[0], ys[0])
+	for index := 1; index < len(xs); index++ {
+		r.LineTo(xs[index], ys[index])
+	}
+	r.Stroke()
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestSparklineRender(t *testing.T) {
+	assert := assert.New(t)
+
+	sparkline := Sparkline{
+		Width:  120,
+		Height: 30,
+		Values: []float64{1, 3, 2, 4},
+		Label:  "4.00",
+		Style: chart.Style{
+			StrokeColor: DefaultCandleUpColor,
+			FillColor:   DefaultCandleUpColor.WithAlpha(64),
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(sparkline.Render(chart.SVG, buffer))
+	svg := buffer.String()
+	assert.Contains(`width="120" height="30"`, svg)
+	assert.Contains(">4.00</text>", svg)
+	// the line spans the canvas, from the left padding to just before the label.
+	assert.Contains("M 2 28", svg)
+	assert.Contains("stroke:rgba(42,190,137,1.0)", svg)
+	assert.Contains("fill:rgba(42,190,137,0.3)", svg)
+}
+
+func TestSparklineRenderFlat(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(Sparkline{Width: 120, Height: 30, Values: []float64{5, 5}}.Render(chart.SVG, buffer))
+	// a flat line is drawn through the middle.
+	assert.Contains("M 2 15", buffer.String())
+
+	buffer.Reset()
+	assert.Nil(Sparkline{Width: 120, Height: 30}.Render(chart.SVG, buffer))
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+// ParseHexColors parses a comma delimited list of hex colors.
+func ParseHexColors(value string) ([]drawing.Color, error) {
+	var colors []drawing.Color
+	for _, part := range strings.Split(value, ",") {
+		color, err := ParseHexColor(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		colors = append(colors, color)
+	}
+	return colors, nil
+}
+
+// ParseDashArray parses a comma delimited list of dash and gap lengths (i.e. `5,5`);
+// `solid` or `0` is a solid line, returned as an empty (non-nil) dash array.
+func ParseDashArray(value string) ([]float64, error) {
+	if value == "solid" || value == "0" {
+		return []float64{}, nil
+	}
+	lengths, err := parsePositiveFloats(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dash array: %s", value)
+	}
+	return lengths, nil
+}
+
+// ParsePadding parses padding in pixels, as either a single value for every side or
+// four comma delimited values in css order (top, right, bottom, left).
+func ParsePadding(value string) (chart.Box, error) {
+	var sides []int
+	for _, part := range strings.Split(value, ",") {
+		side, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || side <= 0 {
+			return chart.Box{}, fmt.Errorf("invalid padding: %s", value)
+		}
+		sides = append(sides, side)
+	}
+	switch len(sides) {
+	case 1:
+		return chart.Box{Top: sides[0], Right: sides[0], Bottom: sides[0], Left: sides[0]}, nil
+	case 4:
+		return chart.Box{Top: sides[0], Right: sides[1], Bottom: sides[2], Left: sides[3]}, nil
+	}
+	return chart.Box{}, fmt.Errorf("invalid padding: %s", value)
+}
+
+// parsePositiveFloats parses a comma delimited list of numbers that must all be greater than zero.
+func parsePositiveFloats(value string) ([]float64, error) {
+	var values []float64
+	for _, part := range strings.Split(value, ",") {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		if parsed <= 0 {
+			return nil, fmt.Errorf("%v is not positive", parsed)
+		}
+		values = append(values, parsed)
+	}
+	return values, nil
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestParseHexColors(t *testing.T) {
+	assert := assert.New(t)
+
+	colors, err := ParseHexColors("2b7bb9, #f00")
+	assert.Nil(err)
+	assert.Len(colors, 2)
+	assert.Equal(drawing.ColorFromHex("2b7bb9"), colors[0])
+	assert.Equal(drawing.ColorFromHex("f00"), colors[1])
+
+	_, err = ParseHexColors("2b7bb9,blue")
+	assert.NotNil(err)
+	_, err = ParseHexColors("")
+	assert.NotNil(err)
+}
+
+func TestParseDashArray(t *testing.T) {
+	assert := assert.New(t)
+
+	dashes, err := ParseDashArray("2, 4")
+	assert.Nil(err)
+	assert.Equal([]float64{2, 4}, dashes)
+
+	dashes, err = ParseDashArray("solid")
+	assert.Nil(err)
+	assert.NotNil(dashes)
+	assert.Empty(dashes)
+
+	_, err = ParseDashArray("2,-4")
+	assert.NotNil(err)
+	_, err = ParseDashArray("dotted")
+	assert.NotNil(err)
+}
+
+func TestParsePadding(t *testing.T) {
+	assert := assert.New(t)
+
+	padding, err := ParsePadding("10")
+	assert.Nil(err)
+	assert.Equal(chart.Box{Top: 10, Right: 10, Bottom: 10, Left: 10}, padding)
+
+	padding, err = ParsePadding("1,2,3,4")
+	assert.Nil(err)
+	assert.Equal(chart.Box{Top: 1, Right: 2, Bottom: 3, Left: 4}, padding)
+
+	_, err = ParsePadding("1,2")
+	assert.NotNil(err)
+	_, err = ParsePadding("0")
+	assert.NotNil(err)
+	_, err = ParsePadding("1.5")
+	assert.NotNil(err)
+}
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"html"
+
+	"github.com/wcharczuk/go-chart"
+)
+
+// SVG returns a `chart.SVG` renderer that escapes text. go-chart writes text into the svg as is, so a title or
+// label with markup in it (i.e. from the query string) would be markup in the image, and in any page embedding it.
+func SVG(width, height int) (chart.Renderer, error) {
+	r, err := chart.SVG(width, height)
+	if err != nil {
+		return nil, err
+	}
+	return escapedRenderer{Renderer: r}, nil
+}
+
+// escapedRenderer escapes text as it's drawn; it's measured as is, since that's how it displays.
+type escapedRenderer struct {
+	chart.Renderer
+}
+
+func (er escapedRenderer) Text(body string, x, y int) {
+	er.Renderer.Text(html.EscapeString(body), x, y)
+}
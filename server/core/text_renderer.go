@@ -0,0 +1,357 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+const (
+	// TextCellWidth is the width of a character cell in pixels, as a typical terminal font draws it.
+	TextCellWidth = 8
+	// TextCellHeight is the height of a character cell in pixels.
+	TextCellHeight = 16
+
+	// brailleWidth is the number of braille dots across a character cell.
+	brailleWidth = 2
+	// brailleHeight is the number of braille dots down a character cell.
+	brailleHeight = 4
+	// dotWidth and dotHeight are the size of a braille dot in pixels.
+	dotWidth  = TextCellWidth / brailleWidth
+	dotHeight = TextCellHeight / brailleHeight
+
+	// textCurveSegments is the number of line segments used for curves.
+	textCurveSegments = 8
+	// brailleBlank is the braille character with no dots raised.
+	brailleBlank = 0x2800
+)
+
+// brailleDots are the bits of each dot in a braille character, by row then column.
+var brailleDots = [brailleHeight][brailleWidth]uint8{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Text returns a renderer that draws charts as unicode braille characters for terminals. Charts are laid out
+// in pixels as a terminal would show them, `TextCellWidth` by `TextCellHeight` to a character, so a chart
+// `cols` characters wide is `cols * TextCellWidth` pixels wide. Lines are drawn in the 2x4 braille dots of
+// each character, and text is set a character to a cell.
+func Text(width, height int) (chart.Renderer, error) {
+	return newTextRenderer(width, height, false), nil
+}
+
+// ANSI returns a text renderer that colors the lines with 256 color ansi escape codes; text is
+// left in the terminal's color.
+func ANSI(width, height int) (chart.Renderer, error) {
+	return newTextRenderer(width, height, true), nil
+}
+
+func newTextRenderer(width, height int, useColor bool) *textRenderer {
+	cols, rows := (width+TextCellWidth-1)/TextCellWidth, (height+TextCellHeight-1)/TextCellHeight
+	tr := &textRenderer{
+		width:    width,
+		height:   height,
+		dpi:      chart.DefaultDPI,
+		useColor: useColor,
+		dots:     make([][]uint8, rows),
+		colors:   make([][]drawing.Color, rows),
+		text:     make([][]rune, rows),
+	}
+	for row := 0; row < rows; row++ {
+		tr.dots[row] = make([]uint8, cols)
+		tr.colors[row] = make([]drawing.Color, cols)
+		tr.text[row] = make([]rune, cols)
+	}
+	return tr
+}
+
+// textSegment is a line between two points.
+type textSegment struct {
+	x0, y0, x1, y1 float64
+}
+
+// textRenderer rasterizes strokes into braille dots and places text over them by cell. Fills are
+// ignored, as they would hide the lines beneath them.
+type textRenderer struct {
+	width, height int
+	dpi           float64
+	useColor      bool
+	style         chart.Style
+	textTheta     *float64
+
+	dots   [][]uint8
+	colors [][]drawing.Color
+	text   [][]rune
+
+	path           []textSegment
+	x, y           float64
+	startX, startY float64
+}
+
+// ResetStyle implements chart.Renderer.
+func (tr *textRenderer) ResetStyle() {
+	tr.style = chart.Style{Font: tr.style.Font}
+	tr.textTheta = nil
+}
+
+// GetDPI implements chart.Renderer.
+func (tr *textRenderer) GetDPI() float64 {
+	return tr.dpi
+}
+
+// SetDPI implements chart.Renderer.
+func (tr *textRenderer) SetDPI(dpi float64) {
+	tr.dpi = dpi
+}
+
+// SetStrokeColor implements chart.Renderer.
+func (tr *textRenderer) SetStrokeColor(c drawing.Color) {
+	tr.style.StrokeColor = c
+}
+
+// SetFillColor implements chart.Renderer.
+func (tr *textRenderer) SetFillColor(c drawing.Color) {
+	tr.style.FillColor = c
+}
+
+// SetStrokeWidth implements chart.Renderer; every line is one dot wide.
+func (tr *textRenderer) SetStrokeWidth(width float64) {
+	tr.style.StrokeWidth = width
+}
+
+// SetStrokeDashArray implements chart.Renderer.
+func (tr *textRenderer) SetStrokeDashArray(dashArray []float64) {
+	tr.style.StrokeDashArray = dashArray
+}
+
+// MoveTo implements chart.Renderer.
+func (tr *textRenderer) MoveTo(x, y int) {
+	tr.x, tr.y = float64(x), float64(y)
+	tr.startX, tr.startY = tr.x, tr.y
+}
+
+// LineTo implements chart.Renderer.
+func (tr *textRenderer) LineTo(x, y int) {
+	tr.lineTo(float64(x), float64(y))
+}
+
+// QuadCurveTo implements chart.Renderer.
+func (tr *textRenderer) QuadCurveTo(cx, cy, x, y int) {
+	x0, y0 := tr.x, tr.y
+	for index := 1; index <= textCurveSegments; index++ {
+		t := float64(index) / textCurveSegments
+		tr.lineTo(
+			(1-t)*(1-t)*x0+2*(1-t)*t*float64(cx)+t*t*float64(x),
+			(1-t)*(1-t)*y0+2*(1-t)*t*float64(cy)+t*t*float64(y),
+		)
+	}
+}
+
+// ArcTo implements chart.Renderer.
+func (tr *textRenderer) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) {
+	for index := 0; index <= textCurveSegments; index++ {
+		angle := startAngle + delta*float64(index)/textCurveSegments
+		x, y := float64(cx)+math.Cos(angle)*rx, float64(cy)+math.Sin(angle)*ry
+		if index == 0 && len(tr.path) == 0 {
+			tr.x, tr.y, tr.startX, tr.startY = x, y, x, y
+		} else {
+			tr.lineTo(x, y)
+		}
+	}
+}
+
+// Close implements chart.Renderer.
+func (tr *textRenderer) Close() {
+	tr.lineTo(tr.startX, tr.startY)
+}
+
+// Stroke implements chart.Renderer.
+func (tr *textRenderer) Stroke() {
+	tr.stroke(false)
+}
+
+// Fill implements chart.Renderer; fills aren't drawn.
+func (tr *textRenderer) Fill() {
+	tr.path = nil
+}
+
+// FillStroke implements chart.Renderer; only the stroke is drawn.
+func (tr *textRenderer) FillStroke() {
+	tr.stroke(true)
+}
+
+// Circle implements chart.Renderer; circles are too small to draw as more than their center.
+func (tr *textRenderer) Circle(radius float64, x, y int) {
+	tr.MoveTo(x, y)
+	tr.LineTo(x, y)
+}
+
+// SetFont implements chart.Renderer; text is set in the terminal's font.
+func (tr *textRenderer) SetFont(f *truetype.Font) {
+	tr.style.Font = f
+}
+
+// SetFontColor implements chart.Renderer.
+func (tr *textRenderer) SetFontColor(c drawing.Color) {
+	tr.style.FontColor = c
+}
+
+// SetFontSize implements chart.Renderer; text is always one character to a cell.
+func (tr *textRenderer) SetFontSize(size float64) {
+	tr.style.FontSize = size
+}
+
+// Text implements chart.Renderer, placing the text in the cells around its baseline; rotated text is written
+// down a column. Control characters (i.e. escape sequences in a title) are replaced, so the text can't control
+// the terminal it's printed to.
+func (tr *textRenderer) Text(body string, x, y int) {
+	if tr.style.FontColor.IsTransparent() {
+		return
+	}
+	col, row := x/TextCellWidth, (y-TextCellHeight/2)/TextCellHeight
+	vertical := tr.textTheta != nil && math.Abs(math.Sin(*tr.textTheta)) > 0.5
+	for _, r := range body {
+		if unicode.IsControl(r) {
+			r = unicode.ReplacementChar
+		}
+		if row >= 0 && row < len(tr.text) && col >= 0 && col < len(tr.text[row]) {
+			tr.text[row][col] = r
+		}
+		if vertical {
+			row++
+		} else {
+			col++
+		}
+	}
+}
+
+// MeasureText implements chart.Renderer; each character is a cell.
+func (tr *textRenderer) MeasureText(body string) chart.Box {
+	box := chart.Box{Right: utf8.RuneCountInString(body) * TextCellWidth, Bottom: TextCellHeight}
+	if tr.textTheta == nil {
+		return box
+	}
+	return box.Corners().Rotate(util.Math.RadiansToDegrees(*tr.textTheta)).Box()
+}
+
+// SetTextRotation implements chart.Renderer.
+func (tr *textRenderer) SetTextRotation(radians float64) {
+	tr.textTheta = &radians
+}
+
+// ClearTextRotation implements chart.Renderer.
+func (tr *textRenderer) ClearTextRotation() {
+	tr.textTheta = nil
+}
+
+// Save implements chart.Renderer, writing a line per row of cells.
+func (tr *textRenderer) Save(w io.Writer) error {
+	output := bytes.NewBuffer(nil)
+	for row := range tr.text {
+		line := bytes.NewBuffer(nil)
+		var color string
+		for col := range tr.text[row] {
+			var cellColor string
+			r := tr.text[row][col]
+			if r == 0 {
+				r = ' '
+				if dots := tr.dots[row][col]; dots != 0 {
+					r = rune(brailleBlank + int(dots))
+					cellColor = ansiColor(tr.colors[row][col])
+				}
+			}
+			if tr.useColor && cellColor != color {
+				if len(color) > 0 {
+					line.WriteString(ansiReset)
+				}
+				line.WriteString(cellColor)
+				color = cellColor
+			}
+			line.WriteRune(r)
+		}
+		if len(color) > 0 {
+			line.WriteString(ansiReset)
+		}
+		output.WriteString(strings.TrimRight(line.String(), " "))
+		output.WriteString("\n")
+	}
+	_, err := w.Write(output.Bytes())
+	return err
+}
+
+func (tr *textRenderer) lineTo(x, y float64) {
+	tr.path = append(tr.path, textSegment{x0: tr.x, y0: tr.y, x1: x, y1: y})
+	tr.x, tr.y = x, y
+}
+
+// stroke draws the path a dot at a time, skipping the gaps of the dash array, and starts a new path.
+func (tr *textRenderer) stroke(fill bool) {
+	defer func() {
+		tr.path = nil
+	}()
+	// shapes stroked in their fill color (i.e. backgrounds) would only be outlines here.
+	if tr.style.StrokeColor.IsTransparent() || (fill && tr.style.StrokeColor.Equals(tr.style.FillColor)) {
+		return
+	}
+	dashes := tr.style.StrokeDashArray
+	var dashLength float64
+	for _, dash := range dashes {
+		dashLength += dash
+	}
+
+	var distance float64
+	for _, segment := range tr.path {
+		dx, dy := segment.x1-segment.x0, segment.y1-segment.y0
+		length := math.Sqrt(dx*dx + dy*dy)
+		// step a quarter dot at a time, so diagonal lines don't skip dots.
+		steps := util.Math.MaxInt(int(math.Ceil(4*length/dotWidth)), 1)
+		for step := 0; step <= steps; step++ {
+			t := float64(step) / float64(steps)
+			if dashLength == 0 || isDash(dashes, math.Mod(distance+t*length, dashLength)) {
+				tr.plot(int(segment.x0+t*dx+0.5), int(segment.y0+t*dy+0.5))
+			}
+		}
+		distance += length
+	}
+}
+
+// isDash returns if a distance into a dash array is within a dash rather than a gap.
+func isDash(dashes []float64, position float64) bool {
+	for index, dash := range dashes {
+		if position < dash {
+			return index%2 == 0
+		}
+		position -= dash
+	}
+	return false
+}
+
+func (tr *textRenderer) plot(x, y int) {
+	if x < 0 || y < 0 || x >= tr.width || y >= tr.height {
+		return
+	}
+	row, col := y/TextCellHeight, x/TextCellWidth
+	tr.dots[row][col] |= brailleDots[(y%TextCellHeight)/dotHeight][(x%TextCellWidth)/dotWidth]
+	tr.colors[row][col] = tr.style.StrokeColor
+}
+
+const ansiReset = "\x1b[0m"
+
+// ansiColor returns the escape code for the closest color in the 6x6x6 cube of the 256 color palette.
+func ansiColor(c drawing.Color) string {
+	level := func(value uint8) int {
+		return (int(value)*5 + 127) / 255
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", 16+36*level(c.R)+6*level(c.G)+level(c.B))
+}
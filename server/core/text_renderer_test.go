@@ -0,0 +1,127 @@
+package core
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func saveText(assert *assert.Assertions, r chart.Renderer) string {
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(r.Save(buffer))
+	return buffer.String()
+}
+
+func TestTextRendererStroke(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := Text(2*TextCellWidth, 2*TextCellHeight)
+	assert.Nil(err)
+	r.SetStrokeColor(chart.ColorBlack)
+	r.MoveTo(0, 0)
+	r.LineTo(2*TextCellWidth-1, 0)
+	r.Stroke()
+	r.MoveTo(0, TextCellHeight)
+	r.LineTo(0, 2*TextCellHeight-1)
+	r.Stroke()
+	assert.Equal("⠉⠉\n⡇\n", saveText(assert, r))
+}
+
+func TestTextRendererDashes(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := Text(4*TextCellWidth, TextCellHeight)
+	assert.Nil(err)
+	r.SetStrokeColor(chart.ColorBlack)
+	r.SetStrokeDashArray([]float64{TextCellWidth, TextCellWidth})
+	r.MoveTo(0, 0)
+	r.LineTo(4*TextCellWidth-1, 0)
+	r.Stroke()
+	assert.Equal("⠉ ⠉\n", saveText(assert, r))
+}
+
+func TestTextRendererFills(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := Text(2*TextCellWidth, TextCellHeight)
+	assert.Nil(err)
+	box := chart.Box{Right: 2*TextCellWidth - 1, Bottom: TextCellHeight - 1}
+	chart.Draw.Box(r, box, chart.Style{FillColor: chart.ColorWhite, StrokeColor: chart.ColorWhite, StrokeWidth: 1})
+	assert.Equal("\n", saveText(assert, r), "backgrounds aren't outlined")
+
+	chart.Draw.Box(r, box, chart.Style{FillColor: chart.ColorWhite, StrokeColor: chart.ColorBlack, StrokeWidth: 1})
+	assert.Equal("⣏⣹\n", saveText(assert, r))
+}
+
+func TestTextRendererText(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := Text(4*TextCellWidth, 3*TextCellHeight)
+	assert.Nil(err)
+	r.SetStrokeColor(chart.ColorBlack)
+	r.MoveTo(0, TextCellHeight)
+	r.LineTo(4*TextCellWidth-1, TextCellHeight)
+	r.Stroke()
+
+	r.SetFontColor(chart.ColorBlack)
+	assert.Equal(chart.Box{Right: 2 * TextCellWidth, Bottom: TextCellHeight}, r.MeasureText("σ1"))
+	r.Text("σ1", TextCellWidth, 2*TextCellHeight-1)
+	assert.Equal("\n⠉σ1⠉\n\n", saveText(assert, r), "text replaces the dots of its cells")
+
+	r.SetTextRotation(math.Pi / 2)
+	r.Text("ab", 0, TextCellHeight-1)
+	assert.Equal("a\nbσ1⠉\n\n", saveText(assert, r), "rotated text is written down a column")
+}
+
+func TestTextRendererTextControlCharacters(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := ANSI(8*TextCellWidth, TextCellHeight)
+	assert.Nil(err)
+	r.SetFontColor(chart.ColorBlack)
+	r.Text("\x1b[2Ja\tb\u009b", 0, TextCellHeight-1)
+	assert.Equal("\ufffd[2Ja\ufffdb\ufffd\n", saveText(assert, r), "control characters are replaced")
+}
+
+func TestTextRendererANSI(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := ANSI(3*TextCellWidth, TextCellHeight)
+	assert.Nil(err)
+	red := drawing.Color{R: 255, A: 255}
+	r.SetStrokeColor(red)
+	r.MoveTo(0, 0)
+	r.LineTo(TextCellWidth-1, 0)
+	r.Stroke()
+	r.SetFontColor(red)
+	r.Text("x", 2*TextCellWidth, TextCellHeight-1)
+	assert.Equal("\x1b[38;5;196m⠉\x1b[0m x\n", saveText(assert, r), "only lines are colored")
+}
+
+func TestTextRenderChart(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := chart.Chart{
+		Width:  60 * TextCellWidth,
+		Height: 12 * TextCellHeight,
+		XAxis:  chart.XAxis{Style: chart.StyleShow()},
+		YAxis:  chart.YAxis{Style: chart.StyleShow()},
+		Series: []chart.Series{
+			chart.ContinuousSeries{
+				XValues: []float64{1, 2, 3, 4},
+				YValues: []float64{10, 12, 11, 14},
+			},
+		},
+	}
+	buffer := bytes.NewBuffer(nil)
+	assert.Nil(graph.Render(Text, buffer))
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	assert.Len(lines, 12)
+	assert.Contains("14.00", buffer.String())
+	assert.Contains("4.00", lines[len(lines)-1])
+}
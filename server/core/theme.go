@@ -0,0 +1,347 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+	util "github.com/wcharczuk/go-chart/util"
+)
+
+const (
+	// ThemeLight is the default theme; dark text and lines on white.
+	ThemeLight = "light"
+	// ThemeDark is light text and lines on a dark background.
+	ThemeDark = "dark"
+	// ThemeHighContrast is black on white with saturated, widely separated series colors.
+	ThemeHighContrast = "high_contrast"
+	// ThemeColorblind uses the Okabe-Ito palette, which stays distinguishable with common color vision deficiencies.
+	ThemeColorblind = "colorblind"
+)
+
+// Theme is a named set of chart colors.
+// It implements `chart.ColorPalette` for the background, canvas, axes, text and default series colors;
+// the rest are applied to the series and annotations that use them.
+type Theme struct {
+	Name string
+
+	Background     drawing.Color
+	Canvas         drawing.Color
+	Axis           drawing.Color
+	Text           drawing.Color
+	Grid           drawing.Color
+	ZeroLine       drawing.Color
+	AnnotationFill drawing.Color
+
+	// Up and Down color candles, bars and changes by direction.
+	Up   drawing.Color
+	Down drawing.Color
+
+	MovingAverage            drawing.Color
+	ExponentialMovingAverage drawing.Color
+	Bands                    drawing.Color
+	Regression               drawing.Color
+
+	// Series are the colors for the ticker and each comparison, in order.
+	Series []drawing.Color
+}
+
+// BackgroundColor implements chart.ColorPalette.
+func (t Theme) BackgroundColor() drawing.Color {
+	return t.Background
+}
+
+// BackgroundStrokeColor implements chart.ColorPalette.
+func (t Theme) BackgroundStrokeColor() drawing.Color {
+	return t.Background
+}
+
+// CanvasColor implements chart.ColorPalette.
+func (t Theme) CanvasColor() drawing.Color {
+	return t.Canvas
+}
+
+// CanvasStrokeColor implements chart.ColorPalette.
+func (t Theme) CanvasStrokeColor() drawing.Color {
+	return t.Canvas
+}
+
+// AxisStrokeColor implements chart.ColorPalette.
+func (t Theme) AxisStrokeColor() drawing.Color {
+	return t.Axis
+}
+
+// TextColor implements chart.ColorPalette.
+func (t Theme) TextColor() drawing.Color {
+	return t.Text
+}
+
+// GetSeriesColor implements chart.ColorPalette.
+// NOTE: the index will wrap around (using a modulo).
+func (t Theme) GetSeriesColor(index int) drawing.Color {
+	if len(t.Series) == 0 {
+		return chart.GetDefaultColor(index)
+	}
+	return t.Series[index%len(t.Series)]
+}
+
+// WithSeriesColors returns a copy of the theme with the first series colors replaced, in order.
+func (t Theme) WithSeriesColors(colors ...drawing.Color) Theme {
+	series := make([]drawing.Color, util.Math.MaxInt(len(t.Series), len(colors)))
+	copy(series, t.Series)
+	copy(series, colors)
+	t.Series = series
+	return t
+}
+
+// GetUpDownColor returns the up color if the value is rising, and the down color otherwise.
+func (t Theme) GetUpDownColor(isUp bool) drawing.Color {
+	return getUpDownColor(isUp, t.Up, t.Down)
+}
+
+var (
+	themesLock sync.RWMutex
+	themes     = map[string]Theme{
+		ThemeLight: {
+			Name:                     ThemeLight,
+			Background:               drawing.ColorWhite,
+			Canvas:                   drawing.ColorWhite,
+			Axis:                     chart.DefaultAxisColor,
+			Text:                     chart.DefaultTextColor,
+			Grid:                     drawing.ColorFromHex("000"),
+			ZeroLine:                 drawing.ColorFromHex("cccccc"),
+			AnnotationFill:           drawing.ColorWhite,
+			Up:                       DefaultCandleUpColor,
+			Down:                     DefaultCandleDownColor,
+			MovingAverage:            drawing.ColorRed,
+			ExponentialMovingAverage: drawing.ColorBlue,
+			Bands:                    drawing.ColorFromHex("efefef"),
+			Regression:               drawing.ColorFromHex("FFA500"),
+			Series: []drawing.Color{
+				chart.ColorBlue,
+				chart.ColorGreen,
+				chart.ColorRed,
+				chart.ColorCyan,
+				chart.ColorOrange,
+				drawing.ColorFromHex("9467bd"),
+				drawing.ColorFromHex("8c564b"),
+				drawing.ColorFromHex("e377c2"),
+				drawing.ColorFromHex("7f7f7f"),
+				drawing.ColorFromHex("bcbd22"),
+				drawing.ColorFromHex("1f3a93"),
+			},
+		},
+		ThemeDark: {
+			Name:                     ThemeDark,
+			Background:               drawing.ColorFromHex("1e1e1e"),
+			Canvas:                   drawing.ColorFromHex("1e1e1e"),
+			Axis:                     drawing.ColorFromHex("9e9e9e"),
+			Text:                     drawing.ColorFromHex("e0e0e0"),
+			Grid:                     drawing.ColorFromHex("5a5a5a"),
+			ZeroLine:                 drawing.ColorFromHex("6e6e6e"),
+			AnnotationFill:           drawing.ColorFromHex("2d2d2d"),
+			Up:                       drawing.ColorFromHex("26a69a"),
+			Down:                     drawing.ColorFromHex("ef5350"),
+			MovingAverage:            drawing.ColorFromHex("ff7043"),
+			ExponentialMovingAverage: drawing.ColorFromHex("42a5f5"),
+			Bands:                    drawing.ColorFromHex("4a4a4a"),
+			Regression:               drawing.ColorFromHex("ffca28"),
+			Series: []drawing.Color{
+				drawing.ColorFromHex("4fc3f7"),
+				drawing.ColorFromHex("81c784"),
+				drawing.ColorFromHex("e57373"),
+				drawing.ColorFromHex("4dd0e1"),
+				drawing.ColorFromHex("ffb74d"),
+				drawing.ColorFromHex("ba68c8"),
+				drawing.ColorFromHex("a1887f"),
+				drawing.ColorFromHex("f06292"),
+				drawing.ColorFromHex("bdbdbd"),
+				drawing.ColorFromHex("dce775"),
+				drawing.ColorFromHex("7986cb"),
+			},
+		},
+		ThemeHighContrast: {
+			Name:                     ThemeHighContrast,
+			Background:               drawing.ColorWhite,
+			Canvas:                   drawing.ColorWhite,
+			Axis:                     drawing.ColorBlack,
+			Text:                     drawing.ColorBlack,
+			Grid:                     drawing.ColorBlack,
+			ZeroLine:                 drawing.ColorBlack,
+			AnnotationFill:           drawing.ColorWhite,
+			Up:                       drawing.ColorFromHex("006400"),
+			Down:                     drawing.ColorFromHex("c00000"),
+			MovingAverage:            drawing.ColorFromHex("c00000"),
+			ExponentialMovingAverage: drawing.ColorFromHex("0000c0"),
+			Bands:                    drawing.ColorFromHex("b0b0b0"),
+			Regression:               drawing.ColorFromHex("ff8c00"),
+			Series: []drawing.Color{
+				drawing.ColorBlack,
+				drawing.ColorFromHex("0000c0"),
+				drawing.ColorFromHex("c00000"),
+				drawing.ColorFromHex("006400"),
+				drawing.ColorFromHex("8b008b"),
+				drawing.ColorFromHex("ff8c00"),
+				drawing.ColorFromHex("008b8b"),
+			},
+		},
+		ThemeColorblind: {
+			Name:                     ThemeColorblind,
+			Background:               drawing.ColorWhite,
+			Canvas:                   drawing.ColorWhite,
+			Axis:                     chart.DefaultAxisColor,
+			Text:                     chart.DefaultTextColor,
+			Grid:                     drawing.ColorFromHex("000"),
+			ZeroLine:                 drawing.ColorFromHex("cccccc"),
+			AnnotationFill:           drawing.ColorWhite,
+			Up:                       drawing.ColorFromHex("0072b2"),
+			Down:                     drawing.ColorFromHex("d55e00"),
+			MovingAverage:            drawing.ColorFromHex("e69f00"),
+			ExponentialMovingAverage: drawing.ColorFromHex("cc79a7"),
+			Bands:                    drawing.ColorFromHex("e5e5e5"),
+			Regression:               drawing.ColorFromHex("56b4e9"),
+			Series: []drawing.Color{
+				drawing.ColorFromHex("0072b2"),
+				drawing.ColorFromHex("e69f00"),
+				drawing.ColorFromHex("009e73"),
+				drawing.ColorFromHex("d55e00"),
+				drawing.ColorFromHex("cc79a7"),
+				drawing.ColorFromHex("56b4e9"),
+				drawing.ColorFromHex("f0e442"),
+				drawing.ColorBlack,
+			},
+		},
+	}
+)
+
+// GetTheme returns a registered theme by name (case insensitive); an empty name is the light theme.
+func GetTheme(name string) (Theme, error) {
+	if len(name) == 0 {
+		name = ThemeLight
+	}
+
+	themesLock.RLock()
+	defer themesLock.RUnlock()
+	if theme, hasTheme := themes[strings.ToLower(name)]; hasTheme {
+		return theme, nil
+	}
+	return Theme{}, fmt.Errorf("invalid theme: %s", name)
+}
+
+// RegisterTheme adds a theme to the registry, replacing any theme with the same name.
+func RegisterTheme(theme Theme) error {
+	if len(theme.Name) == 0 {
+		return fmt.Errorf("theme requires a name")
+	}
+	themesLock.Lock()
+	defer themesLock.Unlock()
+	themes[strings.ToLower(theme.Name)] = theme
+	return nil
+}
+
+// hexColorExpr matches 3 or 6 digit hex colors, with or without a leading `#`.
+var hexColorExpr = regexp.MustCompile(`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ThemeConfig is a custom theme as it's read from config, with colors as hex strings.
+// Colors that are unset are inherited from the `base` theme (light by default).
+type ThemeConfig struct {
+	Name string `json:"name"`
+	Base string `json:"base"`
+
+	Background               string   `json:"background"`
+	Canvas                   string   `json:"canvas"`
+	Axis                     string   `json:"axis"`
+	Text                     string   `json:"text"`
+	Grid                     string   `json:"grid"`
+	ZeroLine                 string   `json:"zero_line"`
+	AnnotationFill           string   `json:"annotation_fill"`
+	Up                       string   `json:"up"`
+	Down                     string   `json:"down"`
+	MovingAverage            string   `json:"sma"`
+	ExponentialMovingAverage string   `json:"ema"`
+	Bands                    string   `json:"bands"`
+	Regression               string   `json:"regression"`
+	Series                   []string `json:"series"`
+}
+
+// Theme returns the theme for the config.
+func (tc ThemeConfig) Theme() (Theme, error) {
+	theme, err := GetTheme(tc.Base)
+	if err != nil {
+		return theme, err
+	}
+	theme.Name = tc.Name
+
+	colors := []struct {
+		value  string
+		target *drawing.Color
+	}{
+		{tc.Background, &theme.Background},
+		{tc.Canvas, &theme.Canvas},
+		{tc.Axis, &theme.Axis},
+		{tc.Text, &theme.Text},
+		{tc.Grid, &theme.Grid},
+		{tc.ZeroLine, &theme.ZeroLine},
+		{tc.AnnotationFill, &theme.AnnotationFill},
+		{tc.Up, &theme.Up},
+		{tc.Down, &theme.Down},
+		{tc.MovingAverage, &theme.MovingAverage},
+		{tc.ExponentialMovingAverage, &theme.ExponentialMovingAverage},
+		{tc.Bands, &theme.Bands},
+		{tc.Regression, &theme.Regression},
+	}
+	for _, color := range colors {
+		if len(color.value) == 0 {
+			continue
+		}
+		if *color.target, err = ParseHexColor(color.value); err != nil {
+			return theme, err
+		}
+	}
+
+	if len(tc.Series) > 0 {
+		theme.Series = make([]drawing.Color, len(tc.Series))
+		for index, value := range tc.Series {
+			if theme.Series[index], err = ParseHexColor(value); err != nil {
+				return theme, err
+			}
+		}
+	}
+	return theme, nil
+}
+
+// LoadThemes reads a json array of theme configs from a file and registers each theme.
+func LoadThemes(path string) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var configs []ThemeConfig
+	if err = json.Unmarshal(contents, &configs); err != nil {
+		return err
+	}
+	for _, config := range configs {
+		theme, err := config.Theme()
+		if err != nil {
+			return fmt.Errorf("theme %s: %v", config.Name, err)
+		}
+		if err = RegisterTheme(theme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseHexColor parses a 3 or 6 digit hex color, with or without a leading `#`.
+func ParseHexColor(value string) (drawing.Color, error) {
+	if !hexColorExpr.MatchString(value) {
+		return drawing.Color{}, fmt.Errorf("invalid hex color: %s", value)
+	}
+	return drawing.ColorFromHex(strings.TrimPrefix(value, "#")), nil
+}
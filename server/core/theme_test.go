@@ -0,0 +1,104 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+func TestGetTheme(t *testing.T) {
+	assert := assert.New(t)
+
+	theme, err := GetTheme("")
+	assert.Nil(err)
+	assert.Equal(ThemeLight, theme.Name)
+	assert.Equal(drawing.ColorWhite, theme.BackgroundColor())
+	assert.Equal(DefaultCandleUpColor, theme.Up)
+
+	theme, err = GetTheme("Dark")
+	assert.Nil(err)
+	assert.Equal(ThemeDark, theme.Name)
+
+	for _, name := range []string{ThemeHighContrast, ThemeColorblind} {
+		theme, err = GetTheme(name)
+		assert.Nil(err)
+		assert.NotEmpty(theme.Series)
+	}
+
+	_, err = GetTheme("not_a_theme")
+	assert.NotNil(err)
+}
+
+func TestThemeGetSeriesColor(t *testing.T) {
+	assert := assert.New(t)
+
+	theme := Theme{Series: []drawing.Color{drawing.ColorRed, drawing.ColorBlue}}
+	assert.Equal(drawing.ColorRed, theme.GetSeriesColor(0))
+	assert.Equal(drawing.ColorBlue, theme.GetSeriesColor(1))
+	assert.Equal(drawing.ColorRed, theme.GetSeriesColor(2))
+
+	assert.Equal(chart.GetDefaultColor(1), Theme{}.GetSeriesColor(1))
+}
+
+func TestThemeConfigTheme(t *testing.T) {
+	assert := assert.New(t)
+
+	theme, err := ThemeConfig{
+		Name:       "solarized",
+		Base:       ThemeDark,
+		Background: "#002b36",
+		Up:         "859900",
+		Series:     []string{"268bd2", "#b58900"},
+	}.Theme()
+	assert.Nil(err)
+	assert.Equal("solarized", theme.Name)
+	assert.Equal(drawing.ColorFromHex("002b36"), theme.Background)
+	assert.Equal(drawing.ColorFromHex("859900"), theme.Up)
+	assert.Len(theme.Series, 2)
+	assert.Equal(drawing.ColorFromHex("b58900"), theme.GetSeriesColor(1))
+
+	dark, err := GetTheme(ThemeDark)
+	assert.Nil(err)
+	assert.Equal(dark.Down, theme.Down, "unset colors come from the base theme")
+
+	_, err = ThemeConfig{Name: "bad", Text: "fffff"}.Theme()
+	assert.NotNil(err)
+	_, err = ThemeConfig{Name: "bad", Base: "not_a_theme"}.Theme()
+	assert.NotNil(err)
+}
+
+func TestLoadThemes(t *testing.T) {
+	assert := assert.New(t)
+
+	file, err := ioutil.TempFile("", "themes")
+	assert.Nil(err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`[{"name": "Test_Custom", "base": "light", "background": "#fafafa", "series": ["111", "222"]}]`)
+	assert.Nil(err)
+	assert.Nil(file.Close())
+
+	assert.Nil(LoadThemes(file.Name()))
+	theme, err := GetTheme("test_custom")
+	assert.Nil(err)
+	assert.Equal(drawing.ColorFromHex("fafafa"), theme.Background)
+	assert.Equal(drawing.ColorFromHex("222"), theme.GetSeriesColor(1))
+
+	assert.NotNil(LoadThemes(file.Name() + ".missing"))
+}
+
+func TestThemeWithSeriesColors(t *testing.T) {
+	assert := assert.New(t)
+
+	light, err := GetTheme(ThemeLight)
+	assert.Nil(err)
+
+	theme := light.WithSeriesColors(drawing.ColorBlack)
+	assert.Equal(drawing.ColorBlack, theme.GetSeriesColor(0))
+	assert.Equal(light.GetSeriesColor(1), theme.GetSeriesColor(1))
+	assert.Len(theme.Series, len(light.Series))
+	assert.NotEqual(drawing.ColorBlack, light.GetSeriesColor(0), "the original theme is unchanged")
+}
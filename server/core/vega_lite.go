@@ -0,0 +1,268 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wcharczuk/go-chart"
+	"github.com/wcharczuk/go-chart/drawing"
+)
+
+const (
+	// VegaLiteSchema is the vega-lite version the specs are written against.
+	VegaLiteSchema = "https://vega.github.io/schema/vega-lite/v2.json"
+
+	// VegaLiteTemporal is the field type of time x values.
+	VegaLiteTemporal = "temporal"
+	// VegaLiteOrdinal is the field type of x values that are plotted in order, like renko bricks.
+	VegaLiteOrdinal = "ordinal"
+)
+
+// VegaLite is a vega-lite view specification; a chart is a vertical concatenation of panels, each of which is a
+// layer per series.
+type VegaLite struct {
+	Schema    string                 `json:"$schema,omitempty"`
+	Title     string                 `json:"title,omitempty"`
+	Width     int                    `json:"width,omitempty"`
+	Height    int                    `json:"height,omitempty"`
+	Data      *VegaLiteData          `json:"data,omitempty"`
+	Mark      *VegaLiteMark          `json:"mark,omitempty"`
+	Encoding  *VegaLiteEncoding      `json:"encoding,omitempty"`
+	Selection map[string]interface{} `json:"selection,omitempty"`
+	Layer     []VegaLite             `json:"layer,omitempty"`
+	VConcat   []VegaLite             `json:"vconcat,omitempty"`
+	Resolve   *VegaLiteResolve       `json:"resolve,omitempty"`
+}
+
+// VegaLiteData is inline data for a view.
+type VegaLiteData struct {
+	Values []map[string]interface{} `json:"values"`
+}
+
+// VegaLiteMark is how a series is drawn.
+type VegaLiteMark struct {
+	Type        string    `json:"type"`
+	Color       string    `json:"color,omitempty"`
+	Opacity     float64   `json:"opacity,omitempty"`
+	StrokeWidth float64   `json:"strokeWidth,omitempty"`
+	StrokeDash  []float64 `json:"strokeDash,omitempty"`
+}
+
+// VegaLiteEncoding maps the data fields to the visual channels.
+type VegaLiteEncoding struct {
+	X       *VegaLiteChannel  `json:"x,omitempty"`
+	Y       *VegaLiteChannel  `json:"y,omitempty"`
+	Y2      *VegaLiteChannel  `json:"y2,omitempty"`
+	Color   *VegaLiteChannel  `json:"color,omitempty"`
+	Tooltip []VegaLiteChannel `json:"tooltip,omitempty"`
+}
+
+// VegaLiteChannel is the encoding of a data field.
+type VegaLiteChannel struct {
+	Field  string         `json:"field"`
+	Type   string         `json:"type,omitempty"`
+	Title  string         `json:"title,omitempty"`
+	Format string         `json:"format,omitempty"`
+	Axis   *VegaLiteAxis  `json:"axis,omitempty"`
+	Scale  *VegaLiteScale `json:"scale,omitempty"`
+}
+
+// VegaLiteAxis is the axis of a position channel.
+type VegaLiteAxis struct {
+	Title  string `json:"title,omitempty"`
+	Format string `json:"format,omitempty"`
+	Grid   bool   `json:"grid"`
+}
+
+// VegaLiteScale is the scale of a channel.
+type VegaLiteScale struct {
+	Type   string   `json:"type,omitempty"`
+	Zero   *bool    `json:"zero,omitempty"`
+	Domain []string `json:"domain,omitempty"`
+	Range  []string `json:"range,omitempty"`
+}
+
+// VegaLiteResolve is how the scales of layers are combined.
+type VegaLiteResolve struct {
+	Scale map[string]string `json:"scale"`
+}
+
+// VegaLitePanel is a chart to draw as a panel of a vega-lite spec.
+type VegaLitePanel struct {
+	Height int
+	// XType is the field type of the x values, `VegaLiteTemporal` or `VegaLiteOrdinal`.
+	XType string
+	// XValue returns the data value for an x value of the series, i.e. a timestamp.
+	XValue  func(x float64) interface{}
+	XTitle  string
+	XFormat string
+	YTitle  string
+	// YFormat is the d3 format of the y values, i.e. `.2f`.
+	YFormat     string
+	UseLogScale bool
+	ShowGrid    bool
+	ShowLegend  bool
+	Series      []chart.Series
+}
+
+// NewVegaLite returns a vega-lite spec of the panels stacked top to bottom, sharing a width. Each series that is
+// shown is a layer with its values inline: lines for values, areas for bollinger bands and bars for histograms,
+// candles and boxes. Series on the secondary y axis are layered against their own scale.
+func NewVegaLite(title string, width int, panels ...VegaLitePanel) VegaLite {
+	spec := VegaLite{
+		Schema: VegaLiteSchema,
+		Title:  title,
+	}
+	for _, panel := range panels {
+		spec.VConcat = append(spec.VConcat, newVegaLitePanel(width, panel))
+	}
+	return spec
+}
+
+func newVegaLitePanel(width int, panel VegaLitePanel) VegaLite {
+	var names, colors []string
+	var primary, secondary []VegaLite
+	for _, s := range panel.Series {
+		if !s.GetStyle().Show {
+			continue
+		}
+		layer, ok := newVegaLiteLayer(panel, s, len(names))
+		if !ok {
+			continue
+		}
+		names = append(names, s.GetName())
+		colors = append(colors, layer.Mark.Color)
+		if s.GetYAxis() == chart.YAxisSecondary {
+			secondary = append(secondary, layer)
+		} else {
+			primary = append(primary, layer)
+		}
+	}
+
+	if panel.ShowLegend {
+		color := &VegaLiteChannel{Field: "series", Type: "nominal", Scale: &VegaLiteScale{Domain: names, Range: colors}}
+		for index := range primary {
+			primary[index].Encoding.Color = color
+		}
+		for index := range secondary {
+			secondary[index].Encoding.Color = color
+		}
+	}
+	// zooming and panning the time axis.
+	if len(primary) > 0 {
+		primary[0].Selection = map[string]interface{}{
+			"zoom": map[string]interface{}{"type": "interval", "bind": "scales", "encodings": []string{"x"}},
+		}
+	}
+
+	view := VegaLite{Width: width, Height: panel.Height}
+	if len(secondary) == 0 {
+		view.Layer = primary
+		return view
+	}
+	view.Layer = []VegaLite{{Layer: primary}, {Layer: secondary}}
+	view.Resolve = &VegaLiteResolve{Scale: map[string]string{"y": "independent"}}
+	return view
+}
+
+// newVegaLiteLayer returns the layer for a series, or false if the series doesn't have values to plot.
+func newVegaLiteLayer(panel VegaLitePanel, s chart.Series, index int) (VegaLite, bool) {
+	style := s.GetStyle()
+	mark := &VegaLiteMark{StrokeWidth: style.StrokeWidth, StrokeDash: style.StrokeDashArray}
+	values := []map[string]interface{}{}
+	isBounded := false
+
+	if _, isHistogram := s.(chart.HistogramSeries); isHistogram {
+		mark.Type = "bar"
+	} else if _, isBands := s.(*chart.BollingerBandsSeries); isBands {
+		mark.Type = "area"
+	} else if _, isValuesProvider := s.(chart.ValuesProvider); isValuesProvider {
+		mark.Type = "line"
+	} else if _, isBoundedValuesProvider := s.(chart.BoundedValuesProvider); isBoundedValuesProvider {
+		mark.Type = "bar"
+	} else {
+		return VegaLite{}, false
+	}
+
+	if typed, isValuesProvider := s.(chart.ValuesProvider); isValuesProvider {
+		for i := 0; i < typed.Len(); i++ {
+			x, y := typed.GetValues(i)
+			if isVegaLiteValue(y) {
+				values = append(values, map[string]interface{}{"x": panel.XValue(x), "y": y, "series": s.GetName()})
+			}
+		}
+	} else if typed, isBoundedValuesProvider := s.(chart.BoundedValuesProvider); isBoundedValuesProvider {
+		isBounded = true
+		for i := 0; i < typed.Len(); i++ {
+			x, y0, y1 := typed.GetBoundedValues(i)
+			if isVegaLiteValue(y0) && isVegaLiteValue(y1) {
+				values = append(values, map[string]interface{}{"x": panel.XValue(x), "y": math.Max(y0, y1), "y2": math.Min(y0, y1), "series": s.GetName()})
+			}
+		}
+	}
+
+	color := style.StrokeColor
+	if mark.Type != "line" && !style.FillColor.IsZero() {
+		color = style.FillColor
+	}
+	if color.IsZero() {
+		color = chart.GetDefaultColor(index)
+	}
+	mark.Color = hexColor(color)
+	if color.A < 255 {
+		mark.Opacity = float64(color.A) / 255.0
+	}
+
+	var xscale *VegaLiteScale
+	if panel.XType == VegaLiteTemporal {
+		xscale = &VegaLiteScale{Type: "utc"}
+	}
+	// prices are plotted around their range, as they are on the images.
+	zero := false
+	yscale := &VegaLiteScale{Zero: &zero}
+	if panel.UseLogScale && s.GetYAxis() == chart.YAxisPrimary {
+		yscale.Type = "log"
+	}
+	encoding := &VegaLiteEncoding{
+		X: &VegaLiteChannel{
+			Field: "x",
+			Type:  panel.XType,
+			Axis:  &VegaLiteAxis{Title: panel.XTitle, Format: panel.XFormat, Grid: panel.ShowGrid},
+			Scale: xscale,
+		},
+		Y: &VegaLiteChannel{
+			Field: "y",
+			Type:  "quantitative",
+			Axis:  &VegaLiteAxis{Title: panel.YTitle, Format: panel.YFormat, Grid: panel.ShowGrid},
+			Scale: yscale,
+		},
+		Tooltip: []VegaLiteChannel{
+			{Field: "x", Type: panel.XType, Title: panel.XTitle, Format: panel.XFormat},
+		},
+	}
+	if isBounded {
+		encoding.Y2 = &VegaLiteChannel{Field: "y2"}
+		encoding.Tooltip = append(encoding.Tooltip,
+			VegaLiteChannel{Field: "y", Type: "quantitative", Title: s.GetName() + " High", Format: panel.YFormat},
+			VegaLiteChannel{Field: "y2", Type: "quantitative", Title: s.GetName() + " Low", Format: panel.YFormat},
+		)
+	} else {
+		encoding.Tooltip = append(encoding.Tooltip, VegaLiteChannel{Field: "y", Type: "quantitative", Title: s.GetName(), Format: panel.YFormat})
+	}
+
+	return VegaLite{
+		Data:     &VegaLiteData{Values: values},
+		Mark:     mark,
+		Encoding: encoding,
+	}, true
+}
+
+// isVegaLiteValue returns if a value can be written as json.
+func isVegaLiteValue(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// hexColor returns the css hex code for a color, without its alpha.
+func hexColor(c drawing.Color) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
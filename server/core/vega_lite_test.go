@@ -0,0 +1,85 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestNewVegaLite(t *testing.T) {
+	assert := assert.New(t)
+
+	price := chart.ContinuousSeries{
+		Name:    "TEST",
+		Style:   chart.Style{Show: true, StrokeColor: chart.ColorBlue, StrokeWidth: 2},
+		XValues: []float64{1, 2, 3},
+		YValues: []float64{10, 12, 11},
+	}
+	bands := &chart.BollingerBandsSeries{Name: "TEST BB", Style: chart.Style{Show: true, FillColor: chart.ColorBlue.WithAlpha(64)}, InnerSeries: price, Period: 2, K: 1}
+	compare := chart.ContinuousSeries{Name: "CMP", Style: chart.StyleShow(), YAxis: chart.YAxisSecondary, XValues: []float64{1}, YValues: []float64{100}}
+	hidden := chart.ContinuousSeries{Name: "Hidden", XValues: []float64{1}, YValues: []float64{1}}
+	annotation := chart.AnnotationSeries{Name: "Last", Style: chart.StyleShow()}
+
+	spec := NewVegaLite("TEST", 400, VegaLitePanel{
+		Height:  200,
+		XType:   VegaLiteOrdinal,
+		XValue:  func(x float64) interface{} { return int(x) },
+		YFormat: ".2f",
+		Series:  []chart.Series{bands, price, compare, hidden, annotation},
+	})
+	assert.Equal(VegaLiteSchema, spec.Schema)
+	assert.Len(spec.VConcat, 1)
+
+	panel := spec.VConcat[0]
+	assert.Equal(400, panel.Width)
+	assert.Equal(200, panel.Height)
+	assert.Equal("independent", panel.Resolve.Scale["y"], "the secondary axis has its own scale")
+	assert.Len(panel.Layer, 2)
+	assert.Len(panel.Layer[0].Layer, 2)
+	assert.Len(panel.Layer[1].Layer, 1)
+
+	area := panel.Layer[0].Layer[0]
+	assert.Equal("area", area.Mark.Type)
+	assert.Equal("#0074d9", area.Mark.Color)
+	assert.InDelta(64.0/255.0, area.Mark.Opacity, 0.0001)
+	assert.Equal("y2", area.Encoding.Y2.Field)
+	assert.NotNil(area.Selection["zoom"])
+	assert.Len(area.Data.Values, 3)
+	assert.Equal(12.0, area.Data.Values[1]["y"])
+
+	line := panel.Layer[0].Layer[1]
+	assert.Equal("line", line.Mark.Type)
+	assert.Equal(2.0, line.Mark.StrokeWidth)
+	assert.Equal(map[string]interface{}{"x": 2, "y": 12.0, "series": "TEST"}, line.Data.Values[1])
+	assert.Equal(".2f", line.Encoding.Y.Axis.Format)
+	assert.Equal("TEST", line.Encoding.Tooltip[1].Title)
+	assert.Nil(line.Encoding.Color, "the legend is off")
+
+	contents, err := json.Marshal(spec)
+	assert.Nil(err)
+	assert.Contains(`"$schema":"https://vega.github.io/schema/vega-lite/v2.json"`, string(contents))
+}
+
+func TestNewVegaLiteLegend(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := NewVegaLite("", 400, VegaLitePanel{
+		XType:      VegaLiteTemporal,
+		XValue:     func(x float64) interface{} { return x },
+		ShowLegend: true,
+		Series: []chart.Series{
+			chart.ContinuousSeries{Name: "A", Style: chart.StyleShow(), XValues: []float64{1}, YValues: []float64{1}},
+			chart.ContinuousSeries{Name: "B", Style: chart.StyleShow(), XValues: []float64{1}, YValues: []float64{2}},
+		},
+	})
+	panel := spec.VConcat[0]
+	assert.Nil(panel.Resolve)
+	assert.Len(panel.Layer, 2)
+	color := panel.Layer[1].Encoding.Color
+	assert.Equal("series", color.Field)
+	assert.Equal([]string{"A", "B"}, color.Scale.Domain)
+	assert.Equal([]string{panel.Layer[0].Mark.Color, panel.Layer[1].Mark.Color}, color.Scale.Range)
+	assert.Equal("utc", panel.Layer[0].Encoding.X.Scale.Type)
+}
@@ -2,6 +2,9 @@ package core
 
 import (
 	"crypto/hmac"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/blendlabs/go-util"
 	"github.com/blendlabs/go-web"
@@ -55,6 +58,17 @@ func ReadQueryValue(rc *web.Ctx, key, defaultValue string) string {
 	return defaultValue
 }
 
+// ReadQueryValues reads every value of a repeatable query parameter, dropping empty values.
+func ReadQueryValues(rc *web.Ctx, key string) []string {
+	var values []string
+	for _, value := range rc.Request.URL.Query()[key] {
+		if len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // ReadQueryValueInt reads a query value with a default.
 func ReadQueryValueInt(rc *web.Ctx, key string, defaultValue int) int {
 	if value, err := rc.QueryParamInt(key); err == nil {
@@ -78,3 +92,25 @@ func ReadQueryValueBool(rc *web.Ctx, key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// IsNotModified returns if the client already has the response with the validators, per its
+// `If-None-Match` or, failing that, its `If-Modified-Since` header.
+func IsNotModified(rc *web.Ctx, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := rc.Request.Header.Get("If-None-Match"); len(ifNoneMatch) > 0 {
+		for _, tag := range strings.Split(ifNoneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(rc.Request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
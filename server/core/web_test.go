@@ -3,6 +3,7 @@ package core
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/blendlabs/go-assert"
 	"github.com/blendlabs/go-web"
@@ -53,3 +54,24 @@ func TestAuthRequiredDev(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(http.StatusOK, meta.StatusCode)
 }
+
+func TestIsNotModified(t *testing.T) {
+	assert := assert.New(t)
+
+	lastModified := time.Date(2017, 05, 01, 20, 0, 0, 500, time.UTC)
+	isNotModified := func(header, value string) bool {
+		rc, err := web.NewMockRequestBuilder(nil).WithHeader(header, value).Ctx(nil)
+		assert.Nil(err)
+		return IsNotModified(rc, `"abc"`, lastModified)
+	}
+
+	assert.True(isNotModified("If-None-Match", `"abc"`))
+	assert.True(isNotModified("If-None-Match", `"xyz", W/"abc"`))
+	assert.True(isNotModified("If-None-Match", "*"))
+	assert.False(isNotModified("If-None-Match", `"xyz"`))
+	assert.True(isNotModified("If-Modified-Since", lastModified.Format(http.TimeFormat)))
+	assert.True(isNotModified("If-Modified-Since", lastModified.Add(time.Hour).Format(http.TimeFormat)))
+	assert.False(isNotModified("If-Modified-Since", lastModified.Add(-time.Hour).Format(http.TimeFormat)))
+	assert.False(isNotModified("If-Modified-Since", "not a date"))
+	assert.False(isNotModified("X-Other", "value"))
+}
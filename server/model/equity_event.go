@@ -0,0 +1,146 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/blendlabs/spiffy"
+	m "github.com/blendlabs/spiffy/migration"
+)
+
+const (
+	// EquityEventKindEarnings is an earnings release.
+	EquityEventKindEarnings = "earnings"
+	// EquityEventKindDividend is an ex-dividend date; the value is the dividend per share.
+	EquityEventKindDividend = "dividend"
+	// EquityEventKindSplit is a stock split; the value is the number of new shares per old share.
+	EquityEventKindSplit = "split"
+	// EquityEventKindNote is a user defined marker.
+	EquityEventKindNote = "note"
+)
+
+// EquityEvent is a dated event for an equity, drawn as a marker on charts.
+type EquityEvent struct {
+	ID           int       `json:"id" db:"id,pk,serial"`
+	EquityID     int       `json:"equity_id" db:"equity_id"`
+	Kind         string    `json:"kind" db:"kind"`
+	TimestampUTC time.Time `json:"timestamp_utc" db:"timestamp_utc"`
+	Label        string    `json:"label" db:"label"`
+	Value        float64   `json:"value" db:"value"`
+}
+
+// TableName returns the table name
+func (ee EquityEvent) TableName() string {
+	return "equity_event"
+}
+
+// IsZero returns if the object has been set or not.
+func (ee EquityEvent) IsZero() bool {
+	return ee.ID == 0
+}
+
+// Validate returns an error if the event is missing required fields or has an unknown kind.
+func (ee EquityEvent) Validate() error {
+	if ee.EquityID == 0 {
+		return fmt.Errorf("event requires an `equity_id`")
+	}
+	if ee.TimestampUTC.IsZero() {
+		return fmt.Errorf("event requires a `timestamp_utc`")
+	}
+	switch ee.Kind {
+	case EquityEventKindEarnings, EquityEventKindDividend:
+	case EquityEventKindSplit:
+		if ee.Value <= 0 {
+			return fmt.Errorf("split events require a positive `value`")
+		}
+	case EquityEventKindNote:
+		if len(ee.Label) == 0 {
+			return fmt.Errorf("note events require a `label`")
+		}
+	default:
+		return fmt.Errorf("invalid event kind: %s", ee.Kind)
+	}
+	return nil
+}
+
+// GetLabel returns the label, or a default label for the kind if it's unset.
+func (ee EquityEvent) GetLabel() string {
+	if len(ee.Label) > 0 {
+		return ee.Label
+	}
+	switch ee.Kind {
+	case EquityEventKindEarnings:
+		return "Earnings"
+	case EquityEventKindDividend:
+		if ee.Value > 0 {
+			return fmt.Sprintf("Div. %.2f", ee.Value)
+		}
+		return "Div."
+	case EquityEventKindSplit:
+		return fmt.Sprintf("Split %g:1", ee.Value)
+	}
+	return ee.Kind
+}
+
+// Migration returns the migration steps for the model.
+func (ee EquityEvent) Migration() m.Migration {
+	return m.New(
+		"create or update `equity_event`",
+		m.Step(
+			m.CreateTable,
+			m.Body(
+				"CREATE TABLE equity_event (id serial not null, equity_id int not null, kind varchar(32) not null, timestamp_utc timestamp not null, label varchar(255), value numeric(18,4));",
+				"ALTER TABLE equity_event ADD CONSTRAINT pk_equity_event_id PRIMARY KEY (id);",
+				"ALTER TABLE equity_event ADD CONSTRAINT fk_equity_event_equity_id FOREIGN KEY (equity_id) REFERENCES equity(id);",
+				"CREATE INDEX ix_equity_event_equity_id_timestamp_utc ON equity_event (equity_id, timestamp_utc);",
+			),
+			"equity_event",
+		),
+	)
+}
+
+// GetEquityEvents gets all the events for a ticker, in order.
+func GetEquityEvents(ticker string, txs ...*sql.Tx) ([]EquityEvent, error) {
+	var tx *sql.Tx
+	if len(txs) > 0 {
+		tx = txs[0]
+	}
+
+	query := `
+	select ee.* from
+		equity_event ee
+		join equity e on e.id = ee.equity_id
+	where
+		e.ticker ilike $1
+	order by ee.timestamp_utc asc
+	`
+	var events []EquityEvent
+	return events, spiffy.Default().QueryInTx(query, tx, ticker).OutMany(&events)
+}
+
+// GetEquityEventsByDate gets the events for a ticker in a date range, in order.
+func GetEquityEventsByDate(ticker string, start, end time.Time, txs ...*sql.Tx) ([]EquityEvent, error) {
+	var tx *sql.Tx
+	if len(txs) > 0 {
+		tx = txs[0]
+	}
+
+	query := `
+	select ee.* from
+		equity_event ee
+		join equity e on e.id = ee.equity_id
+	where
+		e.ticker ilike $1
+		and ee.timestamp_utc >= $2 and ee.timestamp_utc <= $3
+	order by ee.timestamp_utc asc
+	`
+	var events []EquityEvent
+	return events, spiffy.Default().QueryInTx(query, tx, ticker, start, end).OutMany(&events)
+}
+
+func createTestEquityEvent(equityID int, kind string, timestamp time.Time, tx *sql.Tx) (*EquityEvent, error) {
+	event := EquityEvent{EquityID: equityID, Kind: kind, TimestampUTC: timestamp, Value: 0.5}
+	err := spiffy.Default().CreateInTx(&event, tx)
+	return &event, err
+}
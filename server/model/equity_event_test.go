@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/blendlabs/spiffy"
+)
+
+func TestEquityEventValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now().UTC()
+	assert.Nil(EquityEvent{EquityID: 1, Kind: EquityEventKindEarnings, TimestampUTC: now}.Validate())
+	assert.Nil(EquityEvent{EquityID: 1, Kind: EquityEventKindSplit, TimestampUTC: now, Value: 4}.Validate())
+	assert.NotNil(EquityEvent{EquityID: 1, Kind: EquityEventKindSplit, TimestampUTC: now}.Validate())
+	assert.NotNil(EquityEvent{EquityID: 1, Kind: EquityEventKindNote, TimestampUTC: now}.Validate())
+	assert.NotNil(EquityEvent{EquityID: 1, Kind: "merger", TimestampUTC: now}.Validate())
+	assert.NotNil(EquityEvent{Kind: EquityEventKindEarnings, TimestampUTC: now}.Validate())
+	assert.NotNil(EquityEvent{EquityID: 1, Kind: EquityEventKindEarnings}.Validate())
+}
+
+func TestEquityEventGetLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("Earnings", EquityEvent{Kind: EquityEventKindEarnings}.GetLabel())
+	assert.Equal("Div. 0.57", EquityEvent{Kind: EquityEventKindDividend, Value: 0.57}.GetLabel())
+	assert.Equal("Split 7:1", EquityEvent{Kind: EquityEventKindSplit, Value: 7}.GetLabel())
+	assert.Equal("Investor day", EquityEvent{Kind: EquityEventKindNote, Label: "Investor day"}.GetLabel())
+}
+
+func TestGetEquityEventsByDate(t *testing.T) {
+	assert := assert.New(t)
+	tx, err := spiffy.Default().Begin()
+	assert.Nil(err)
+	defer tx.Rollback()
+
+	eq, err := createTestEquity(tx)
+	assert.Nil(err)
+
+	now := time.Now().UTC()
+	_, err = createTestEquityEvent(eq.ID, EquityEventKindEarnings, now.AddDate(0, 0, -1), tx)
+	assert.Nil(err)
+	_, err = createTestEquityEvent(eq.ID, EquityEventKindDividend, now.AddDate(0, 0, -10), tx)
+	assert.Nil(err)
+	_, err = createTestEquityEvent(eq.ID, EquityEventKindEarnings, now.AddDate(0, 0, -100), tx)
+	assert.Nil(err)
+
+	events, err := GetEquityEventsByDate(eq.Ticker, now.AddDate(0, 0, -30), now, tx)
+	assert.Nil(err)
+	assert.Len(events, 2)
+	assert.Equal(EquityEventKindDividend, events[0].Kind)
+
+	all, err := GetEquityEvents(eq.Ticker, tx)
+	assert.Nil(err)
+	assert.Len(all, 3)
+}
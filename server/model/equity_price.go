@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/blendlabs/spiffy"
@@ -118,6 +119,14 @@ func (ep EquityPrices) Last() *EquityPrice {
 	return nil
 }
 
+// Through returns the prices up to and including a timestamp; the prices are in order.
+func (ep EquityPrices) Through(t time.Time) EquityPrices {
+	index := sort.Search(len(ep), func(i int) bool {
+		return ep[i].TimestampUTC.After(t)
+	})
+	return ep[:index]
+}
+
 // Prices returns the x,y ranges as []time.Time and []float64
 // NOTE: This changes the ep timestamp timezones to eastern.
 func (ep EquityPrices) Prices() ([]time.Time, []float64) {
@@ -152,6 +161,97 @@ func (ep EquityPrices) PercentChange() ([]time.Time, []float64) {
 	return xvalues, yvalues
 }
 
+// Drawdowns returns the x,y ranges as []time.Time and []float64, where y is the
+// percent below the running high of the prices (zero at a new high, negative otherwise).
+func (ep EquityPrices) Drawdowns() ([]time.Time, []float64) {
+	xvalues := make([]time.Time, len(ep))
+	yvalues := make([]float64, len(ep))
+
+	var high float64
+	for x := 0; x < len(ep); x++ {
+		xvalues[x] = ep[x].TimestampUTC
+		if ep[x].Price > high {
+			high = ep[x].Price
+		}
+		if high > 0 {
+			yvalues[x] = util.Math.PercentDifference(high, ep[x].Price)
+		}
+	}
+	return xvalues, yvalues
+}
+
+// MaxDrawdown returns the largest peak to trough decline of the prices.
+func (ep EquityPrices) MaxDrawdown() Drawdown {
+	var dd Drawdown
+	if len(ep) == 0 {
+		return dd
+	}
+
+	var peak, trough, runningPeak int
+	var maxDepth float64
+	for x := 1; x < len(ep); x++ {
+		if ep[x].Price > ep[runningPeak].Price {
+			runningPeak = x
+			continue
+		}
+		if ep[runningPeak].Price > 0 {
+			depth := util.Math.PercentDifference(ep[runningPeak].Price, ep[x].Price)
+			if depth < maxDepth {
+				maxDepth = depth
+				peak, trough = runningPeak, x
+			}
+		}
+	}
+	if maxDepth == 0 {
+		return dd
+	}
+
+	dd.Peak = ep[peak]
+	dd.Trough = ep[trough]
+	for x := trough + 1; x < len(ep); x++ {
+		if ep[x].Price >= dd.Peak.Price {
+			recovery := ep[x]
+			dd.Recovery = &recovery
+			break
+		}
+	}
+	return dd
+}
+
+// Drawdown is a peak to trough decline in price, and the recovery (if any) back to the peak.
+type Drawdown struct {
+	Peak     EquityPrice
+	Trough   EquityPrice
+	Recovery *EquityPrice
+}
+
+// IsZero returns if the drawdown has been set or not.
+func (dd Drawdown) IsZero() bool {
+	return dd.Trough.TimestampUTC.IsZero()
+}
+
+// Depth returns the percent decline from the peak to the trough.
+func (dd Drawdown) Depth() float64 {
+	if dd.Peak.Price == 0 {
+		return 0
+	}
+	return util.Math.PercentDifference(dd.Peak.Price, dd.Trough.Price)
+}
+
+// IsRecovered returns if the price returned to the peak after the trough.
+func (dd Drawdown) IsRecovered() bool {
+	return dd.Recovery != nil
+}
+
+// RecoveryDuration returns the time from the trough back to the peak price.
+// It returns zero if the price has not recovered.
+func (dd Drawdown) RecoveryDuration() time.Duration {
+	if dd.Recovery == nil {
+		return 0
+	}
+	return dd.Recovery.TimestampUTC.Sub(dd.Trough.TimestampUTC)
+}
+
 // Len returns the length.
 func (ep EquityPrices) Len() int {
 	return len(ep)
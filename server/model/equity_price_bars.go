@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// BarInterval is the period of time each bar covers.
+type BarInterval int
+
+const (
+	// BarIntervalDaily is one bar per trading session; the bars as fetched.
+	BarIntervalDaily BarInterval = iota
+	// BarIntervalWeekly is one bar per exchange week (Monday through Friday).
+	BarIntervalWeekly
+	// BarIntervalMonthly is one bar per calendar month.
+	BarIntervalMonthly
+)
+
+// ParseBarInterval parses a bar interval from a string (`1d`, `1w` or `1mo`).
+func ParseBarInterval(value string) (BarInterval, error) {
+	switch strings.ToLower(value) {
+	case "", "1d":
+		return BarIntervalDaily, nil
+	case "1w", "1wk":
+		return BarIntervalWeekly, nil
+	case "1mo":
+		return BarIntervalMonthly, nil
+	}
+	return BarIntervalDaily, fmt.Errorf("invalid interval: %s", value)
+}
+
+// Historical returns only the prices that carry open, high, low and close values (i.e. not live quotes).
+func (ep EquityPrices) Historical() EquityPrices {
+	var bars EquityPrices
+	for _, p := range ep {
+		if p.IsHistorical {
+			bars = append(bars, p)
+		}
+	}
+	return bars
+}
+
+// HeikinAshi returns the Heikin-Ashi bars computed from the open, high, low and close of the prices.
+// Each bar's close is the average of the raw bar, its open is the midpoint of the previous Heikin-Ashi
+// bar's body, and its high and low are widened to include that open and close.
+func (ep EquityPrices) HeikinAshi() EquityPrices {
+	bars := make(EquityPrices, len(ep))
+	for x := 0; x < len(ep); x++ {
+		raw := ep[x]
+		bar := raw
+		bar.Close = (raw.Open + raw.High + raw.Low + raw.Close) / 4
+		if x == 0 {
+			bar.Open = (raw.Open + raw.Close) / 2
+		} else {
+			bar.Open = (bars[x-1].Open + bars[x-1].Close) / 2
+		}
+		bar.High = math.Max(raw.High, math.Max(bar.Open, bar.Close))
+		bar.Low = math.Min(raw.Low, math.Min(bar.Open, bar.Close))
+		bar.Price = bar.Close
+		bars[x] = bar
+	}
+	return bars
+}
+
+// Resample combines daily bars into one bar per interval; the first open, the highest high, the lowest low,
+// the last close and the total volume. Each bar is stamped with the last session it covers, so weeks and
+// months shortened by holidays end on the last day the exchange was open rather than a calendar boundary.
+// Bars are grouped by the date of their timestamp, as historical bars are dated at midnight.
+func (ep EquityPrices) Resample(interval BarInterval) EquityPrices {
+	if interval == BarIntervalDaily {
+		return ep
+	}
+
+	var bars EquityPrices
+	var lastPeriod time.Time
+	for _, p := range ep.sorted() {
+		open, high, low, close := p.bar()
+		if period := interval.periodOf(p.TimestampUTC); len(bars) == 0 || !period.Equal(lastPeriod) {
+			lastPeriod = period
+			p.Open, p.High, p.Low, p.Close = open, high, low, close
+			bars = append(bars, p)
+			continue
+		}
+
+		bar := &bars[len(bars)-1]
+		bar.TimestampUTC = p.TimestampUTC
+		bar.Price, bar.Close = p.Price, close
+		bar.High = math.Max(bar.High, high)
+		bar.Low = math.Min(bar.Low, low)
+		bar.Volume += p.Volume
+		bar.IsHistorical = bar.IsHistorical && p.IsHistorical
+	}
+	return bars
+}
+
+// periodOf returns the first day of the interval period that contains the timestamp's date.
+func (bi BarInterval) periodOf(timestamp time.Time) time.Time {
+	year, month, day := timestamp.Date()
+	switch bi {
+	case BarIntervalWeekly:
+		// weekdays count from sunday; exchange weeks start on monday.
+		return time.Date(year, month, day-(int(timestamp.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case BarIntervalMonthly:
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// bar returns the open, high, low and close of the price; live quotes only have a price.
+func (ep EquityPrice) bar() (open, high, low, close float64) {
+	if !ep.IsHistorical {
+		return ep.Price, ep.Price, ep.Price, ep.Price
+	}
+	return ep.Open, ep.High, ep.Low, ep.Close
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func testBars(ohlc ...[4]float64) EquityPrices {
+	prices := testEquityPrices(make([]float64, len(ohlc))...)
+	for index, bar := range ohlc {
+		prices[index].IsHistorical = true
+		prices[index].Open = bar[0]
+		prices[index].High = bar[1]
+		prices[index].Low = bar[2]
+		prices[index].Close = bar[3]
+		prices[index].Price = bar[3]
+	}
+	return prices
+}
+
+func TestEquityPricesHistorical(t *testing.T) {
+	assert := assert.New(t)
+
+	prices := append(testBars([4]float64{1, 2, 0.5, 1.5}), testEquityPrices(3)...)
+	bars := prices.Historical()
+	assert.Len(bars, 1)
+	assert.True(bars[0].IsHistorical)
+}
+
+func TestEquityPricesHeikinAshi(t *testing.T) {
+	assert := assert.New(t)
+
+	raw := testBars(
+		[4]float64{10, 12, 9, 11},
+		[4]float64{11, 15, 10, 14},
+		[4]float64{14, 14.5, 8, 9},
+	)
+	bars := raw.HeikinAshi()
+	assert.Len(bars, 3)
+
+	assert.InDelta(10.5, bars[0].Open, 0.0001)
+	assert.InDelta(10.5, bars[0].Close, 0.0001)
+	assert.InDelta(12, bars[0].High, 0.0001)
+	assert.InDelta(9, bars[0].Low, 0.0001)
+
+	assert.InDelta(10.5, bars[1].Open, 0.0001)
+	assert.InDelta(12.5, bars[1].Close, 0.0001)
+	assert.InDelta(15, bars[1].High, 0.0001)
+	assert.InDelta(10, bars[1].Low, 0.0001)
+	assert.Equal(bars[1].Close, bars[1].Price)
+
+	// the open of a bar can sit above the raw high, which widens the bar.
+	assert.InDelta(11.5, bars[2].Open, 0.0001)
+	assert.InDelta(11.375, bars[2].Close, 0.0001)
+	assert.InDelta(14.5, bars[2].High, 0.0001)
+	assert.InDelta(8, bars[2].Low, 0.0001)
+
+	assert.Equal(raw[2].TimestampUTC, bars[2].TimestampUTC)
+	// the raw bars are not modified.
+	assert.Equal(11.0, raw[0].Close)
+}
+
+func TestParseBarInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	interval, err := ParseBarInterval("")
+	assert.Nil(err)
+	assert.Equal(BarIntervalDaily, interval)
+	interval, err = ParseBarInterval("1W")
+	assert.Nil(err)
+	assert.Equal(BarIntervalWeekly, interval)
+	interval, err = ParseBarInterval("1mo")
+	assert.Nil(err)
+	assert.Equal(BarIntervalMonthly, interval)
+	_, err = ParseBarInterval("1y")
+	assert.NotNil(err)
+}
+
+func TestEquityPricesResample(t *testing.T) {
+	assert := assert.New(t)
+
+	// 2017-01-02 (monday) and 2017-01-16 (monday) are exchange holidays.
+	sessions := []time.Time{
+		time.Date(2017, 01, 03, 0, 0, 0, 0, time.UTC),
+		time.Date(2017, 01, 06, 0, 0, 0, 0, time.UTC),
+		time.Date(2017, 01, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2017, 01, 13, 0, 0, 0, 0, time.UTC),
+		time.Date(2017, 01, 17, 0, 0, 0, 0, time.UTC),
+		time.Date(2017, 02, 01, 0, 0, 0, 0, time.UTC),
+	}
+	daily := testBars(
+		[4]float64{10, 12, 9, 11},
+		[4]float64{11, 13, 10, 12},
+		[4]float64{12, 12.5, 8, 9},
+		[4]float64{9, 10, 7, 8},
+		[4]float64{8, 9, 6, 7},
+		[4]float64{7, 8, 7, 8},
+	)
+	for index := range daily {
+		daily[index].TimestampUTC = sessions[index]
+		daily[index].Volume = 100
+	}
+
+	assert.Len(daily.Resample(BarIntervalDaily), len(daily))
+
+	weekly := daily.Resample(BarIntervalWeekly)
+	assert.Len(weekly, 4)
+	assert.Equal(sessions[1], weekly[0].TimestampUTC)
+	assert.Equal(10.0, weekly[0].Open)
+	assert.Equal(13.0, weekly[0].High)
+	assert.Equal(9.0, weekly[0].Low)
+	assert.Equal(12.0, weekly[0].Close)
+	assert.Equal(12.0, weekly[0].Price)
+	assert.Equal(int64(200), weekly[0].Volume)
+	assert.Equal(sessions[4], weekly[2].TimestampUTC)
+
+	monthly := daily.Resample(BarIntervalMonthly)
+	assert.Len(monthly, 2)
+	assert.Equal(sessions[4], monthly[0].TimestampUTC)
+	assert.Equal(10.0, monthly[0].Open)
+	assert.Equal(13.0, monthly[0].High)
+	assert.Equal(6.0, monthly[0].Low)
+	assert.Equal(7.0, monthly[0].Close)
+	assert.Equal(int64(500), monthly[0].Volume)
+	assert.True(monthly[0].IsHistorical)
+
+	// live quotes contribute their price to the high and low.
+	withQuote := append(daily, EquityPrice{TimestampUTC: time.Date(2017, 02, 02, 15, 0, 0, 0, time.UTC), Price: 8.5})
+	monthly = withQuote.Resample(BarIntervalMonthly)
+	assert.Equal(8.5, monthly[1].High)
+	assert.Equal(8.5, monthly[1].Close)
+	assert.False(monthly[1].IsHistorical)
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// boxEpsilon absorbs floating point error when snapping prices to box boundaries.
+const boxEpsilon = 1e-9
+
+// Box is a renko brick or a point & figure column; a span of price from Low to High that ignores time.
+// Start and End are the timestamps of the prices that opened and last extended the box.
+type Box struct {
+	Start time.Time
+	End   time.Time
+	Low   float64
+	High  float64
+	IsUp  bool
+}
+
+// Last returns the most recent extreme of the box; the High of a rising box or the Low of a falling one.
+func (b Box) Last() float64 {
+	if b.IsUp {
+		return b.High
+	}
+	return b.Low
+}
+
+// AverageTrueRange returns the mean true range over the trailing period.
+// Bars without open, high, low and close values (i.e. live quotes) use their price for all three.
+func (ep EquityPrices) AverageTrueRange(period int) float64 {
+	if period < 1 || len(ep) < 2 {
+		return 0
+	}
+
+	var ranges []float64
+	for x := 1; x < len(ep); x++ {
+		high, low := ep[x].High, ep[x].Low
+		if !ep[x].IsHistorical {
+			high, low = ep[x].Price, ep[x].Price
+		}
+		previousClose := ep[x-1].Price
+		ranges = append(ranges, math.Max(high-low, math.Max(math.Abs(high-previousClose), math.Abs(low-previousClose))))
+	}
+	if len(ranges) > period {
+		ranges = ranges[len(ranges)-period:]
+	}
+	return mean(ranges)
+}
+
+// BoxLevels returns how many boxes of a given size the range of the prices spans; it's the most marks a point &
+// figure column can have, and the most bricks a renko trend can have, so it bounds the work of charting them.
+func (ep EquityPrices) BoxLevels(box float64) float64 {
+	if box <= 0 || len(ep) == 0 {
+		return 0
+	}
+	low, high := ep[0].Price, ep[0].Price
+	for _, p := range ep[1:] {
+		low, high = math.Min(low, p.Price), math.Max(high, p.Price)
+	}
+	return (high - low) / box
+}
+
+// Renko returns the renko bricks for the prices with a given box size.
+// A new brick is added each time the price closes a full box beyond the last brick; reversing
+// direction requires a move of two boxes, as the new brick starts from the far side of the last one.
+func (ep EquityPrices) Renko(box float64) []Box {
+	if box <= 0 || len(ep) == 0 {
+		return nil
+	}
+
+	var bricks []Box
+	start := ep[0].TimestampUTC
+	low, high := ep[0].Price, ep[0].Price
+	for _, p := range ep[1:] {
+		for p.Price+boxEpsilon >= high+box {
+			bricks = append(bricks, Box{Start: start, End: p.TimestampUTC, Low: high, High: high + box, IsUp: true})
+			low, high = high, high+box
+			start = p.TimestampUTC
+		}
+		for p.Price-boxEpsilon <= low-box {
+			bricks = append(bricks, Box{Start: start, End: p.TimestampUTC, Low: low - box, High: low})
+			low, high = low-box, low
+			start = p.TimestampUTC
+		}
+	}
+	return bricks
+}
+
+// PointAndFigure returns the point & figure columns for the prices with a given box size and reversal.
+// Each column's Low and High are the price levels of its lowest and highest marks (inclusive).
+// Rising (X) columns extend while the price reaches new levels above them, and a falling (O) column
+// starts one box lower once the price falls `reversal` boxes below the high; and vice versa.
+func (ep EquityPrices) PointAndFigure(box float64, reversal int) []Box {
+	if box <= 0 || reversal < 1 || len(ep) == 0 {
+		return nil
+	}
+
+	floor := func(v float64) float64 { return math.Floor(v/box+boxEpsilon) * box }
+	ceil := func(v float64) float64 { return math.Ceil(v/box-boxEpsilon) * box }
+	swing := float64(reversal) * box
+
+	var columns []Box
+	floorAnchor, ceilAnchor := floor(ep[0].Price), ceil(ep[0].Price)
+	for _, p := range ep[1:] {
+		if len(columns) == 0 {
+			if level := floor(p.Price); level+boxEpsilon >= floorAnchor+box {
+				columns = append(columns, Box{Start: p.TimestampUTC, End: p.TimestampUTC, Low: floorAnchor + box, High: level, IsUp: true})
+			} else if level := ceil(p.Price); level-boxEpsilon <= ceilAnchor-box {
+				columns = append(columns, Box{Start: p.TimestampUTC, End: p.TimestampUTC, Low: level, High: ceilAnchor - box})
+			}
+			continue
+		}
+
+		column := &columns[len(columns)-1]
+		if column.IsUp {
+			if level := floor(p.Price); level > column.High+boxEpsilon {
+				column.High = level
+				column.End = p.TimestampUTC
+			} else if p.Price-boxEpsilon <= column.High-swing {
+				columns = append(columns, Box{Start: p.TimestampUTC, End: p.TimestampUTC, Low: ceil(p.Price), High: column.High - box})
+			}
+		} else {
+			if level := ceil(p.Price); level < column.Low-boxEpsilon {
+				column.Low = level
+				column.End = p.TimestampUTC
+			} else if p.Price+boxEpsilon >= column.Low+swing {
+				columns = append(columns, Box{Start: p.TimestampUTC, End: p.TimestampUTC, Low: column.Low + box, High: floor(p.Price), IsUp: true})
+			}
+		}
+	}
+	return columns
+}
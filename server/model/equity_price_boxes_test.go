@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestEquityPricesAverageTrueRange(t *testing.T) {
+	assert := assert.New(t)
+
+	bars := testBars(
+		[4]float64{10, 11, 9, 10},
+		[4]float64{10, 12, 10, 11},   // high - low = 2
+		[4]float64{11, 11.5, 11, 11}, // high - low = 0.5
+		[4]float64{14, 15, 14, 15},   // gap up; high - previous close = 4
+	)
+	assert.InDelta((2+0.5+4)/3.0, bars.AverageTrueRange(14), 0.0001)
+	assert.InDelta((0.5+4)/2.0, bars.AverageTrueRange(2), 0.0001)
+
+	// live quotes only have a price, so the range is the change in price.
+	assert.InDelta(1.5, testEquityPrices(10, 11, 13).AverageTrueRange(14), 0.0001)
+	assert.Zero(testEquityPrices(10).AverageTrueRange(14))
+}
+
+func TestEquityPricesRenko(t *testing.T) {
+	assert := assert.New(t)
+
+	bricks := testEquityPrices(100, 104, 111, 125, 118, 108, 89).Renko(10)
+	assert.Len(bricks, 4)
+
+	assert.True(bricks[0].IsUp)
+	assert.Equal(100.0, bricks[0].Low)
+	assert.Equal(110.0, bricks[0].High)
+	assert.True(bricks[1].IsUp)
+	assert.Equal(120.0, bricks[1].High)
+
+	// 108 is less than a box below the last brick's low, so it takes 89 to reverse.
+	assert.False(bricks[2].IsUp)
+	assert.Equal(100.0, bricks[2].Low)
+	assert.Equal(110.0, bricks[2].High)
+	assert.False(bricks[3].IsUp)
+	assert.Equal(90.0, bricks[3].Low)
+	assert.Equal(90.0, bricks[3].Last())
+
+	assert.Empty(testEquityPrices(100, 200).Renko(0))
+}
+
+func TestEquityPricesPointAndFigure(t *testing.T) {
+	assert := assert.New(t)
+
+	columns := testEquityPrices(100, 103, 112, 118, 108, 96, 92, 111).PointAndFigure(5, 3)
+	assert.Len(columns, 3)
+
+	assert.True(columns[0].IsUp)
+	assert.Equal(105.0, columns[0].Low)
+	assert.Equal(115.0, columns[0].High)
+
+	// 108 is less than three boxes below 115; 96 is enough to reverse, one box below the high.
+	assert.False(columns[1].IsUp)
+	assert.Equal(110.0, columns[1].High)
+	assert.Equal(95.0, columns[1].Low)
+	assert.Equal(columns[1].Start, columns[1].End.AddDate(0, 0, -1))
+
+	assert.True(columns[2].IsUp)
+	assert.Equal(100.0, columns[2].Low)
+	assert.Equal(110.0, columns[2].High)
+
+	assert.Empty(testEquityPrices(100, 200).PointAndFigure(5, 0))
+}
+
+func TestEquityPricesBoxLevels(t *testing.T) {
+	assert := assert.New(t)
+
+	prices := testEquityPrices(100, 104, 90, 125, 118)
+	assert.InDelta(35.0/5.0, prices.BoxLevels(5), 0.0001)
+	assert.InDelta(35.0/0.0001, prices.BoxLevels(0.0001), 0.01)
+	assert.Zero(prices.BoxLevels(0))
+	assert.Zero(testEquityPrices().BoxLevels(1))
+}
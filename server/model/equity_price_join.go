@@ -0,0 +1,109 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JoinPolicy determines how a timestamp present in only one set of prices is handled by a join.
+type JoinPolicy int
+
+const (
+	// JoinIntersect keeps only the timestamps present in both sets.
+	JoinIntersect JoinPolicy = iota
+	// JoinFillForward keeps the timestamps present in either set, carrying the last known price forward
+	// for the set that is missing it. Timestamps before a set's first price are dropped.
+	JoinFillForward
+)
+
+// ParseJoinPolicy parses a join policy from a string (`intersect` or `fill_forward`).
+func ParseJoinPolicy(value string) (JoinPolicy, error) {
+	switch strings.ToLower(value) {
+	case "", "intersect":
+		return JoinIntersect, nil
+	case "fill_forward", "ffill":
+		return JoinFillForward, nil
+	}
+	return JoinIntersect, fmt.Errorf("invalid join policy: %s", value)
+}
+
+// Join aligns two sets of prices on their timestamps according to the policy.
+// The results are the same length, sorted by timestamp, and the prices at each index share a timestamp.
+func Join(left, right EquityPrices, policy JoinPolicy) (EquityPrices, EquityPrices) {
+	left, right = left.sorted(), right.sorted()
+
+	var joinedLeft, joinedRight EquityPrices
+	var lastLeft, lastRight *EquityPrice
+	var l, r int
+	for l < len(left) || r < len(right) {
+		var timestamp time.Time
+		var hasLeft, hasRight bool
+		switch {
+		case r >= len(right) || (l < len(left) && left[l].TimestampUTC.Before(right[r].TimestampUTC)):
+			timestamp, hasLeft = left[l].TimestampUTC, true
+		case l >= len(left) || right[r].TimestampUTC.Before(left[l].TimestampUTC):
+			timestamp, hasRight = right[r].TimestampUTC, true
+		default:
+			timestamp, hasLeft, hasRight = left[l].TimestampUTC, true, true
+		}
+
+		if hasLeft {
+			lastLeft = &left[l]
+			l++
+		}
+		if hasRight {
+			lastRight = &right[r]
+			r++
+		}
+
+		if hasLeft && hasRight {
+			joinedLeft = append(joinedLeft, *lastLeft)
+			joinedRight = append(joinedRight, *lastRight)
+		} else if policy == JoinFillForward && lastLeft != nil && lastRight != nil {
+			joinedLeft = append(joinedLeft, lastLeft.at(timestamp))
+			joinedRight = append(joinedRight, lastRight.at(timestamp))
+		}
+	}
+	return joinedLeft, joinedRight
+}
+
+// Intersect returns the prices from both sets that fall on timestamps the sets have in common.
+func (ep EquityPrices) Intersect(other EquityPrices) (EquityPrices, EquityPrices) {
+	return Join(ep, other, JoinIntersect)
+}
+
+// Ratio returns the x,y ranges as []time.Time and []float64, where y is the ratio of the prices
+// to the other prices once joined by the policy.
+func (ep EquityPrices) Ratio(other EquityPrices, policy JoinPolicy) ([]time.Time, []float64) {
+	numerator, denominator := Join(ep, other, policy)
+
+	var xvalues []time.Time
+	var yvalues []float64
+	for x := 0; x < len(numerator); x++ {
+		if denominator[x].Price == 0 {
+			continue
+		}
+		xvalues = append(xvalues, numerator[x].TimestampUTC)
+		yvalues = append(yvalues, numerator[x].Price/denominator[x].Price)
+	}
+	return xvalues, yvalues
+}
+
+// sorted returns the prices sorted by timestamp, copying them only if they are out of order.
+func (ep EquityPrices) sorted() EquityPrices {
+	if sort.IsSorted(ep) {
+		return ep
+	}
+	copied := make(EquityPrices, len(ep))
+	copy(copied, ep)
+	sort.Sort(copied)
+	return copied
+}
+
+// at returns a copy of the price at a different timestamp.
+func (ep EquityPrice) at(timestamp time.Time) EquityPrice {
+	ep.TimestampUTC = timestamp
+	return ep
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestJoinIntersect(t *testing.T) {
+	assert := assert.New(t)
+
+	a := testEquityPrices(1, 2, 3, 4)
+	b := testEquityPrices(5, 6, 7, 8)[1:3]
+	left, right := Join(a, b, JoinIntersect)
+	assert.Len(left, 2)
+	assert.Len(right, 2)
+	assert.Equal(2.0, left[0].Price)
+	assert.Equal(6.0, right[0].Price)
+	assert.Equal(left[1].TimestampUTC, right[1].TimestampUTC)
+}
+
+func TestJoinFillForward(t *testing.T) {
+	assert := assert.New(t)
+
+	a := testEquityPrices(1, 2, 3, 4, 5)
+	b := EquityPrices{a[1], a[3]}
+	b[0].Price, b[1].Price = 20, 40
+
+	left, right := Join(a, b, JoinFillForward)
+	// the first timestamp precedes any price in b, so it is dropped.
+	assert.Len(left, 4)
+	assert.Len(right, 4)
+	for index := range left {
+		assert.Equal(left[index].TimestampUTC, right[index].TimestampUTC)
+	}
+	assert.Equal(2.0, left[0].Price)
+	assert.Equal(20.0, right[0].Price)
+	assert.Equal(3.0, left[1].Price)
+	assert.Equal(20.0, right[1].Price)
+	assert.Equal(40.0, right[2].Price)
+	assert.Equal(5.0, left[3].Price)
+	assert.Equal(40.0, right[3].Price)
+}
+
+func TestJoinUnsorted(t *testing.T) {
+	assert := assert.New(t)
+
+	a := testEquityPrices(1, 2, 3)
+	reversed := EquityPrices{a[2], a[1], a[0]}
+	left, right := Join(reversed, a, JoinIntersect)
+	assert.Len(left, 3)
+	assert.Equal(1.0, left[0].Price)
+	assert.Equal(1.0, right[0].Price)
+	// the input is not modified.
+	assert.Equal(3.0, reversed[0].Price)
+}
+
+func TestEquityPricesRatio(t *testing.T) {
+	assert := assert.New(t)
+
+	a := testEquityPrices(10, 12, 15)
+	b := testEquityPrices(5, 0, 3)
+	xvalues, yvalues := a.Ratio(b, JoinIntersect)
+	assert.Len(xvalues, 2)
+	assert.Equal(2.0, yvalues[0])
+	assert.Equal(5.0, yvalues[1])
+	assert.Equal(a[2].TimestampUTC, xvalues[1])
+}
+
+func TestParseJoinPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	policy, err := ParseJoinPolicy("")
+	assert.Nil(err)
+	assert.Equal(JoinIntersect, policy)
+
+	policy, err = ParseJoinPolicy("FILL_FORWARD")
+	assert.Nil(err)
+	assert.Equal(JoinFillForward, policy)
+
+	_, err = ParseJoinPolicy("outer")
+	assert.NotNil(err)
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// TradingDaysPerYear is the number of trading days used to annualize daily statistics.
+	TradingDaysPerYear = 252
+)
+
+// Returns returns the log returns between consecutive prices as []time.Time and []float64.
+// The first price has no prior price and is omitted.
+func (ep EquityPrices) Returns() ([]time.Time, []float64) {
+	if len(ep) < 2 {
+		return nil, nil
+	}
+
+	xvalues := make([]time.Time, len(ep)-1)
+	yvalues := make([]float64, len(ep)-1)
+	for x := 1; x < len(ep); x++ {
+		xvalues[x-1] = ep[x].TimestampUTC
+		if ep[x-1].Price > 0 && ep[x].Price > 0 {
+			yvalues[x-1] = math.Log(ep[x].Price / ep[x-1].Price)
+		}
+	}
+	return xvalues, yvalues
+}
+
+// RollingVolatility returns the annualized realized volatility of the daily returns over a trailing window.
+// Values start once the window has filled.
+func (ep EquityPrices) RollingVolatility(window int) ([]time.Time, []float64) {
+	times, returns := ep.Returns()
+	if window < 2 || len(returns) < window {
+		return nil, nil
+	}
+
+	var xvalues []time.Time
+	var yvalues []float64
+	for x := window; x <= len(returns); x++ {
+		xvalues = append(xvalues, times[x-1])
+		yvalues = append(yvalues, stdDev(returns[x-window:x])*math.Sqrt(TradingDaysPerYear))
+	}
+	return xvalues, yvalues
+}
+
+// RollingBeta returns the beta and correlation of the daily returns versus a benchmark over a trailing window.
+// The prices are intersected with the benchmark on common timestamps first; values start once the window has filled.
+func (ep EquityPrices) RollingBeta(benchmark EquityPrices, window int) (xvalues []time.Time, beta, correlation []float64) {
+	prices, benchmarkPrices := ep.Intersect(benchmark)
+	times, returns := prices.Returns()
+	_, benchmarkReturns := benchmarkPrices.Returns()
+	if window < 2 || len(returns) < window {
+		return
+	}
+
+	for x := window; x <= len(returns); x++ {
+		r, br := returns[x-window:x], benchmarkReturns[x-window:x]
+		cov := covariance(r, br)
+		benchmarkVariance := covariance(br, br)
+		variance := covariance(r, r)
+
+		var b, c float64
+		if benchmarkVariance > 0 {
+			b = cov / benchmarkVariance
+			if variance > 0 {
+				c = cov / math.Sqrt(variance*benchmarkVariance)
+			}
+		}
+		xvalues = append(xvalues, times[x-1])
+		beta = append(beta, b)
+		correlation = append(correlation, c)
+	}
+	return
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+// covariance returns the sample covariance of two equal length sets of values.
+func covariance(a, b []float64) float64 {
+	if len(a) < 2 || len(a) != len(b) {
+		return 0
+	}
+	meanA, meanB := mean(a), mean(b)
+	var total float64
+	for x := 0; x < len(a); x++ {
+		total += (a[x] - meanA) * (b[x] - meanB)
+	}
+	return total / float64(len(a)-1)
+}
+
+func stdDev(values []float64) float64 {
+	return math.Sqrt(covariance(values, values))
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+)
+
+func TestEquityPricesReturns(t *testing.T) {
+	assert := assert.New(t)
+
+	xvalues, yvalues := testEquityPrices(10, 11, 9.9).Returns()
+	assert.Len(xvalues, 2)
+	assert.Len(yvalues, 2)
+	assert.InDelta(math.Log(1.1), yvalues[0], 0.0001)
+	assert.InDelta(math.Log(0.9), yvalues[1], 0.0001)
+
+	xvalues, yvalues = testEquityPrices(10).Returns()
+	assert.Empty(xvalues)
+	assert.Empty(yvalues)
+}
+
+func TestEquityPricesRollingVolatility(t *testing.T) {
+	assert := assert.New(t)
+
+	// constant returns have no volatility.
+	xvalues, yvalues := testEquityPrices(1, 2, 4, 8, 16).RollingVolatility(2)
+	assert.Len(xvalues, 3)
+	for _, v := range yvalues {
+		assert.InDelta(0, v, 0.0001)
+	}
+
+	// alternating returns of +/- r have a sample std. dev. of r*sqrt(2) over a window of 2.
+	_, yvalues = testEquityPrices(100, 110, 100, 110).RollingVolatility(2)
+	assert.Len(yvalues, 2)
+	expected := math.Abs(math.Log(1.1)) * math.Sqrt(2) * math.Sqrt(TradingDaysPerYear)
+	assert.InDelta(expected, yvalues[0], 0.0001)
+
+	xvalues, _ = testEquityPrices(1, 2).RollingVolatility(5)
+	assert.Empty(xvalues)
+}
+
+func TestEquityPricesRollingBeta(t *testing.T) {
+	assert := assert.New(t)
+
+	benchmark := testEquityPrices(100, 102, 101, 104, 103, 105)
+	// a price that moves exactly with the benchmark has a beta and correlation of 1.
+	doubled := testEquityPrices(200, 204, 202, 208, 206, 210)
+	xvalues, beta, correlation := doubled.RollingBeta(benchmark, 3)
+	assert.Len(xvalues, 3)
+	for index := range xvalues {
+		assert.InDelta(1, beta[index], 0.0001)
+		assert.InDelta(1, correlation[index], 0.0001)
+	}
+
+	// prices that only partly overlap the benchmark are intersected first.
+	partial := doubled[2:]
+	xvalues, _, _ = partial.RollingBeta(benchmark, 3)
+	assert.Len(xvalues, 1)
+	assert.Equal(doubled[5].TimestampUTC, xvalues[0])
+}
@@ -32,3 +32,58 @@ func TestGetEquityPricesByDate(t *testing.T) {
 	assert.Nil(err)
 	assert.Len(prices, 3)
 }
+
+func testEquityPrices(prices ...float64) EquityPrices {
+	start := time.Date(2017, 01, 02, 16, 0, 0, 0, time.UTC)
+	output := make(EquityPrices, len(prices))
+	for index, price := range prices {
+		output[index] = EquityPrice{TimestampUTC: start.AddDate(0, 0, index), Price: price}
+	}
+	return output
+}
+
+func TestEquityPricesDrawdowns(t *testing.T) {
+	assert := assert.New(t)
+
+	xvalues, yvalues := testEquityPrices(10, 12, 9, 6, 12, 15).Drawdowns()
+	assert.Len(xvalues, 6)
+	assert.Len(yvalues, 6)
+	assert.Zero(yvalues[0])
+	assert.Zero(yvalues[1])
+	assert.InDelta(-0.25, yvalues[2], 0.0001)
+	assert.InDelta(-0.5, yvalues[3], 0.0001)
+	assert.Zero(yvalues[4])
+	assert.Zero(yvalues[5])
+}
+
+func TestEquityPricesMaxDrawdown(t *testing.T) {
+	assert := assert.New(t)
+
+	prices := testEquityPrices(10, 12, 9, 6, 12, 15, 11)
+	dd := prices.MaxDrawdown()
+	assert.False(dd.IsZero())
+	assert.Equal(12.0, dd.Peak.Price)
+	assert.Equal(6.0, dd.Trough.Price)
+	assert.InDelta(-0.5, dd.Depth(), 0.0001)
+	assert.True(dd.IsRecovered())
+	assert.Equal(prices[4].TimestampUTC, dd.Recovery.TimestampUTC)
+	assert.Equal(24*time.Hour, dd.RecoveryDuration())
+
+	unrecovered := testEquityPrices(10, 8, 9).MaxDrawdown()
+	assert.InDelta(-0.2, unrecovered.Depth(), 0.0001)
+	assert.False(unrecovered.IsRecovered())
+	assert.Zero(unrecovered.RecoveryDuration())
+
+	assert.True(testEquityPrices(1, 2, 3).MaxDrawdown().IsZero())
+	assert.True(EquityPrices(nil).MaxDrawdown().IsZero())
+}
+
+func TestEquityPricesThrough(t *testing.T) {
+	assert := assert.New(t)
+
+	prices := testEquityPrices(10, 12, 9, 6)
+	assert.Len(prices.Through(prices[1].TimestampUTC), 2)
+	assert.Len(prices.Through(prices[1].TimestampUTC.Add(time.Hour)), 2)
+	assert.Empty(prices.Through(prices[0].TimestampUTC.Add(-time.Hour)))
+	assert.Len(prices.Through(prices[3].TimestampUTC), 4)
+}
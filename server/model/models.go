@@ -9,6 +9,8 @@ import (
 var models = []spiffy.DatabaseMapped{
 	Equity{},
 	EquityPrice{},
+	EquityEvent{},
+	Watchlist{},
 }
 
 // Migrate applies migrations.
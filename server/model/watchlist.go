@@ -0,0 +1,99 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/blendlabs/go-util"
+	"github.com/blendlabs/spiffy"
+	m "github.com/blendlabs/spiffy/migration"
+)
+
+// MaxWatchlistTickers is the most tickers a watchlist can have, i.e. the most rows a grid will render.
+const MaxWatchlistTickers = 50
+
+// Watchlist is a saved, named list of tickers.
+type Watchlist struct {
+	ID      int    `json:"id" db:"id,pk,serial"`
+	Name    string `json:"name" db:"name"`
+	Tickers string `json:"tickers" db:"tickers"`
+}
+
+// TableName returns the table name
+func (w Watchlist) TableName() string {
+	return "watchlist"
+}
+
+// IsZero returns if the object has been set or not.
+func (w Watchlist) IsZero() bool {
+	return w.ID == 0
+}
+
+// GetTickers returns the watchlist's comma delimited tickers as a list, in order.
+func (w Watchlist) GetTickers() []string {
+	var tickers []string
+	for _, ticker := range strings.Split(w.Tickers, ",") {
+		if ticker = strings.TrimSpace(ticker); len(ticker) > 0 {
+			tickers = append(tickers, strings.ToUpper(ticker))
+		}
+	}
+	return tickers
+}
+
+// Validate returns an error if the watchlist is unnamed, or its tickers are empty, repeated or too many to chart.
+func (w Watchlist) Validate() error {
+	if len(strings.TrimSpace(w.Name)) == 0 {
+		return fmt.Errorf("watchlist requires a `name`")
+	}
+	tickers := w.GetTickers()
+	if len(tickers) == 0 {
+		return fmt.Errorf("watchlist requires at least one ticker")
+	}
+	if len(tickers) > MaxWatchlistTickers {
+		return fmt.Errorf("watchlist cannot have more than %d tickers", MaxWatchlistTickers)
+	}
+	seen := map[string]bool{}
+	for _, ticker := range tickers {
+		if seen[ticker] {
+			return fmt.Errorf("duplicate ticker: %s", ticker)
+		}
+		seen[ticker] = true
+	}
+	return nil
+}
+
+// Migration returns the migration steps for the model.
+func (w Watchlist) Migration() m.Migration {
+	return m.New(
+		"create or update `watchlist`",
+		m.Step(
+			m.CreateTable,
+			m.Body(
+				"CREATE TABLE watchlist (id serial not null, name varchar(255) not null, tickers text not null);",
+				"ALTER TABLE watchlist ADD CONSTRAINT pk_watchlist_id PRIMARY KEY (id);",
+				"ALTER TABLE watchlist ADD CONSTRAINT uk_watchlist_name UNIQUE (name);",
+			),
+			"watchlist",
+		),
+	)
+}
+
+// GetWatchlistByName gets a watchlist by its name.
+func GetWatchlistByName(name string, txs ...*sql.Tx) (*Watchlist, error) {
+	var tx *sql.Tx
+	if len(txs) > 0 {
+		tx = txs[0]
+	}
+
+	var watchlist Watchlist
+	query := `select * from watchlist where name ilike $1`
+	err := spiffy.Default().QueryInTx(query, tx, name).Out(&watchlist)
+	return &watchlist, err
+}
+
+func createTestWatchlist(tx *sql.Tx) (*Watchlist, error) {
+	watchlist := Watchlist{Name: util.UUIDv4().ToShortString(), Tickers: "aapl, msft,,goog"}
+	err := spiffy.Default().CreateInTx(&watchlist, tx)
+	return &watchlist, err
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/blendlabs/spiffy"
+)
+
+func TestWatchlistValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(Watchlist{Name: "tech", Tickers: "aapl, msft,,goog"}.Validate())
+	assert.NotNil(Watchlist{Name: " ", Tickers: "aapl"}.Validate())
+	assert.NotNil(Watchlist{Name: "tech", Tickers: " , "}.Validate())
+	assert.NotNil(Watchlist{Name: "tech", Tickers: "aapl,msft,AAPL"}.Validate())
+
+	tickers := make([]string, MaxWatchlistTickers+1)
+	for index := range tickers {
+		tickers[index] = fmt.Sprintf("T%d", index)
+	}
+	assert.Nil(Watchlist{Name: "tech", Tickers: strings.Join(tickers[:MaxWatchlistTickers], ",")}.Validate())
+	assert.NotNil(Watchlist{Name: "tech", Tickers: strings.Join(tickers, ",")}.Validate())
+}
+
+func TestWatchlistGetTickers(t *testing.T) {
+	assert := assert.New(t)
+
+	tickers := Watchlist{Tickers: "aapl, msft,,goog "}.GetTickers()
+	assert.Len(tickers, 3)
+	assert.Equal("AAPL", tickers[0])
+	assert.Equal("MSFT", tickers[1])
+	assert.Equal("GOOG", tickers[2])
+	assert.Empty(Watchlist{}.GetTickers())
+}
+
+func TestGetWatchlistByName(t *testing.T) {
+	assert := assert.New(t)
+	tx, err := spiffy.Default().Begin()
+	assert.Nil(err)
+	defer tx.Rollback()
+
+	watchlist, err := createTestWatchlist(tx)
+	assert.Nil(err)
+
+	verify, err := GetWatchlistByName(watchlist.Name, tx)
+	assert.Nil(err)
+	assert.Equal(watchlist.ID, verify.ID)
+	assert.Len(verify.GetTickers(), 3)
+}
@@ -41,7 +41,9 @@ func Init() *web.App {
 	app.Register(controller.Charts{})
 	app.Register(controller.Equities{})
 	app.Register(controller.EquityPrices{})
+	app.Register(controller.EquityEvents{})
 	app.Register(controller.Provider{})
+	app.Register(controller.Watchlists{})
 
 	app.OnStart(func(_ *web.App) error {
 		if app.Logger().IsEnabled(logger.EventDebug) {
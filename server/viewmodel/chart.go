@@ -1,8 +1,12 @@
 package viewmodel
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,42 +25,171 @@ const (
 	defaultChartWidth     = 1024
 	defaultChartHeight    = 400
 	defaultChartTimeframe = "LTM"
+	defaultBetaWindow     = 60
+	defaultATRPeriod      = 14
+	defaultReversal       = 3
+	defaultFillOpacity    = 0.25
+	defaultLegendFontSize = 8.0
+	defaultTextColumns    = 80
+	defaultTextRows       = 24
+	defaultFrames         = 20
+
+	// boxSizeATR derives the renko or point & figure box size from the average true range.
+	boxSizeATR = "atr"
+
+	// maxCompareTickers is the most comparison tickers a single chart will plot.
+	maxCompareTickers = 10
+	// maxReferences is the most reference lines, bands and shaded periods a single chart will draw.
+	maxReferences = 20
+	// minTextColumns, maxTextColumns, minTextRows and maxTextRows bound the size of text charts.
+	minTextColumns = 20
+	maxTextColumns = 400
+	minTextRows    = 8
+	maxTextRows    = 200
+	// minFrames and maxFrames bound the number of frames of an animated chart.
+	minFrames = 2
+	maxFrames = 60
+	// minChartSize and maxChartSize bound the width and height of charts, in pixels.
+	minChartSize = 32
+	maxChartSize = 4096
+	// maxChartPixels bounds the pixels drawn for a chart at its scale, across every frame of an animation.
+	maxChartPixels = 64 * 1024 * 1024
+	// minScale and maxScale bound the pixel density of png and gif charts.
+	minScale = 1.0
+	maxScale = 4.0
+)
+
+const (
+	// chartModePrice plots prices (or percent change) over time.
+	chartModePrice = "price"
+	// chartModeDrawdown plots the percent below the running high over time.
+	chartModeDrawdown = "drawdown"
+	// chartModeRatio plots the ratio of the price to a benchmark (`vs`) over time.
+	chartModeRatio = "ratio"
+	// chartModeRenko plots renko bricks in order, ignoring time.
+	chartModeRenko = "renko"
+	// chartModePointAndFigure plots point & figure columns in order, ignoring time.
+	chartModePointAndFigure = "pnf"
+)
+
+// chartTimeframes are the periods a chart can be drawn over, shortest first.
+var chartTimeframes = []string{"1d", "3d", "1wk", "10d", "1m", "2m", "3m", "6m", "ltm", "2y", "5y"}
+
+// imageFormats are the formats charts, sparklines and grids are drawn in.
+var imageFormats = []string{"png", "svg", "pdf", "txt", "ansi"}
+
+// chartFormats are the formats of a chart; images, the plotted values (csv or json), a vega-lite spec, an
+// interactive page (html) or an animation (gif).
+var chartFormats = append(append([]string{}, imageFormats...), "csv", "json", "vegalite", "html", "gif")
+
+// validateFormat returns an error if the format isn't one of the formats.
+func validateFormat(format string, formats []string) error {
+	for _, valid := range formats {
+		if util.String.CaseInsensitiveEquals(format, valid) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid format: %s; it must be one of %s", format, strings.Join(formats, ", "))
+}
+
+const (
+	// candleTypeCandlestick draws the raw bars as candlesticks.
+	candleTypeCandlestick = "candlestick"
+	// candleTypeHeikinAshi draws Heikin-Ashi bars as candlesticks.
+	candleTypeHeikinAshi = "heikin_ashi"
+	// candleTypeOHLC draws the raw bars as open-high-low-close bars.
+	candleTypeOHLC = "ohlc"
+)
+
+const (
+	// candleFillFilled fills every candle body in its up or down color.
+	candleFillFilled = "filled"
+	// candleFillHollow leaves the bodies of candles that close above their open hollow.
+	candleFillHollow = "hollow"
 )
 
 // Chart are all the chart parameters.
 type Chart struct {
-	Width  int    `query:"width"`
-	Height int    `query:"height"`
-	Format string `query:"format"`
+	Width  int        `query:"width"`
+	Height int        `query:"height"`
+	Format string     `query:"format"`
+	Mode   string     `query:"mode"`
+	Theme  core.Theme `query:"theme"`
+
+	// Columns and Rows size text charts (`format=txt` or `format=ansi`) in characters rather than pixels.
+	Columns int `query:"cols"`
+	Rows    int `query:"rows"`
+
+	// Frames is the number of frames an animated chart (`format=gif`) grows over.
+	Frames int `query:"frames"`
+	// Scale is the pixel density of png and gif charts; they're drawn at `Scale` times the width and height.
+	Scale float64 `query:"scale"`
 
 	ChartTimeframe     string `route:"period"`
 	Start              time.Time
 	End                time.Time
 	ShouldUseDaySeries bool
+	Interval           model.BarInterval `query:"interval"`
 
 	Ticker                   string `route:"ticker"`
 	TickerInfo               *equity.Quote
-	TickerCompare            string `query:"compare"`
-	TickerCompareInfo        *equity.Quote
-	UsePercentageDifferences bool `query:"format"`
-
-	ShowAxes                    bool `query:"show_axes"`
-	ShowGrid                    bool `query:"show_grid"`
-	ShowLastValue               bool `query:"show_last"`
-	ShowLegend                  bool `query:"show_legend"`
-	AddSimpleMovingAverage      bool `query:"add_sma"`
-	AddExponentialMovingAverage bool `query:"add_ema"`
-	AddBollingerBands           bool `query:"add_bb"`
-	AddMACD                     bool `query:"add_macd"`
-	AddLinReg                   bool `query:"add_linreg"`
-	AddPolyReg                  bool `query:"add_polyreg"`
-	AddCandlestick              bool `query:"add_candle"`
+	TickerEquity             *model.Equity
+	TickersCompare           []string `query:"compare"`
+	TickersCompareInfo       []equity.Quote
+	TickerBenchmark          string `query:"beta_vs"`
+	TickerBenchmarkInfo      *equity.Quote
+	TickerVersus             string `query:"vs"`
+	TickerVersusInfo         *equity.Quote
+	JoinPolicy               model.JoinPolicy `query:"join"`
+	UsePercentageDifferences bool             `query:"format"`
+	UseLogScale              bool             `query:"scale"`
+
+	ShowAxes      bool `query:"show_axes"`
+	ShowGrid      bool `query:"show_grid"`
+	ShowLastValue bool `query:"show_last"`
+	ShowLegend    bool `query:"show_legend"`
+	ShowTitle     bool `query:"show_title"`
+	ShowEvents    bool `query:"show_events"`
+
+	ReferenceLines              []core.Level  `query:"hline"`
+	ReferenceBands              []core.Level  `query:"band"`
+	ShadedPeriods               []core.Period `query:"vband"`
+	Title                       string        `query:"title"`
+	AddSimpleMovingAverage      bool          `query:"add_sma"`
+	AddExponentialMovingAverage bool          `query:"add_ema"`
+	AddBollingerBands           bool          `query:"add_bb"`
+	AddMACD                     bool          `query:"add_macd"`
+	AddLinReg                   bool          `query:"add_linreg"`
+	AddPolyReg                  bool          `query:"add_polyreg"`
+	AddCandlestick              bool          `query:"add_candle"`
+	CandleType                  string        `query:"candle_type"`
+	CandleFill                  string        `query:"candle_fill"`
+	VolatilityWindow            int           `query:"add_vol"`
+	BetaWindow                  int           `query:"beta_window"`
+	BoxSize                     float64       `query:"box"`
+	UseATRBoxSize               bool          `query:"box"`
+	Reversal                    int           `query:"reversal"`
+
+	LineWidth     float64   `query:"line_width"`
+	FillOpacity   float64   `query:"fill_opacity"`
+	FontSize      float64   `query:"font_size"`
+	GridDashArray []float64 `query:"grid_dash"`
+	Padding       chart.Box `query:"padding"`
 
 	XValueFormatter chart.ValueFormatter
 	YValueFormatter chart.ValueFormatter
 
-	tickerData        []model.EquityPrice
-	tickerCompareData []model.EquityPrice
+	tickerData          []model.EquityPrice
+	tickersCompareData  [][]model.EquityPrice
+	tickerBenchmarkData []model.EquityPrice
+	tickerVersusData    []model.EquityPrice
+	boxes               []model.Box
+	events              []model.EquityEvent
+
+	// query is the query the chart was requested with, for linking to it in other timeframes.
+	query url.Values
+	// xrangeMax extends the time axis past the end of the data, so the frames of an animation share an axis.
+	xrangeMax time.Time
 
 	K        float64 `query:"k"`
 	Degree   int     `query:"degree"`
@@ -70,17 +203,52 @@ func (c *Chart) Parse(rc *web.Ctx) error {
 	c.Width = core.ReadQueryValueInt(rc, "width", defaultChartWidth)
 	c.Height = core.ReadQueryValueInt(rc, "height", defaultChartHeight)
 
-	c.Format = core.ReadQueryValue(rc, "format", "png")
+	c.Format = strings.ToLower(core.ReadQueryValue(rc, "format", "png"))
+	c.Mode = strings.ToLower(core.ReadQueryValue(rc, "mode", chartModePrice))
+	theme, err := core.GetTheme(core.ReadQueryValue(rc, "theme", core.ThemeLight))
+	if err != nil {
+		return err
+	}
+	c.Theme = theme
+	if err = c.parseStyle(rc); err != nil {
+		return err
+	}
+	if c.isGIFFormat() {
+		c.Frames = core.ReadQueryValueInt(rc, "frames", defaultFrames)
+		if c.Frames < minFrames || c.Frames > maxFrames {
+			return fmt.Errorf("frames must be between %d and %d", minFrames, maxFrames)
+		}
+	}
+	if c.isTextFormat() {
+		if err = c.parseTextSize(rc); err != nil {
+			return err
+		}
+	}
 
 	c.Ticker = core.ReadRouteValue(rc, "ticker", "")
-	c.TickerCompare = core.ReadQueryValue(rc, "compare", "")
+	c.TickersCompare = parseTickers(core.ReadQueryValue(rc, "compare", ""))
+	c.TickerBenchmark = core.ReadQueryValue(rc, "beta_vs", "")
+	c.TickerVersus = core.ReadQueryValue(rc, "vs", "")
 	c.ChartTimeframe = core.ReadRouteValue(rc, "timeframe", defaultChartTimeframe)
+	c.query = rc.Request.URL.Query()
 	c.UsePercentageDifferences = core.ReadQueryValueBool(rc, "use_pct", false)
+	if err = c.parseScale(rc); err != nil {
+		return err
+	}
+	if err = c.validateSize(); err != nil {
+		return err
+	}
 
 	c.ShowGrid = core.ReadQueryValueBool(rc, "show_grid", false)
 	c.ShowAxes = core.ReadQueryValueBool(rc, "show_axes", true)
 	c.ShowLastValue = core.ReadQueryValueBool(rc, "show_last", true)
 	c.ShowLegend = core.ReadQueryValueBool(rc, "show_legend", true)
+	c.Title = core.ReadQueryValue(rc, "title", "")
+	c.ShowTitle = core.ReadQueryValueBool(rc, "show_title", len(c.Title) > 0)
+	c.ShowEvents = core.ReadQueryValueBool(rc, "show_events", false)
+	if err = c.parseReferences(rc); err != nil {
+		return err
+	}
 
 	c.AddSimpleMovingAverage = core.ReadQueryValueBool(rc, "add_sma", false)
 	c.AddExponentialMovingAverage = core.ReadQueryValueBool(rc, "add_ema", false)
@@ -89,6 +257,33 @@ func (c *Chart) Parse(rc *web.Ctx) error {
 	c.AddLinReg = core.ReadQueryValueBool(rc, "add_linreg", false)
 	c.AddPolyReg = core.ReadQueryValueBool(rc, "add_polyreg", false)
 	c.AddCandlestick = core.ReadQueryValueBool(rc, "add_candle", false)
+	c.CandleType = strings.ToLower(core.ReadQueryValue(rc, "candle_type", candleTypeCandlestick))
+	switch c.CandleType {
+	case candleTypeCandlestick:
+	case candleTypeHeikinAshi, candleTypeOHLC:
+		c.AddCandlestick = true
+	default:
+		return fmt.Errorf("invalid candle type: %s", c.CandleType)
+	}
+	c.CandleFill = strings.ToLower(core.ReadQueryValue(rc, "candle_fill", candleFillFilled))
+	if c.CandleFill != candleFillFilled && c.CandleFill != candleFillHollow {
+		return fmt.Errorf("invalid candle fill: %s", c.CandleFill)
+	}
+	c.VolatilityWindow = core.ReadQueryValueInt(rc, "add_vol", 0)
+	c.BetaWindow = core.ReadQueryValueInt(rc, "beta_window", defaultBetaWindow)
+	c.Reversal = core.ReadQueryValueInt(rc, "reversal", defaultReversal)
+	// the box size only means something for renko and point & figure charts.
+	if c.isBoxMode() {
+		if box := strings.ToLower(core.ReadQueryValue(rc, "box", boxSizeATR)); box == boxSizeATR {
+			c.UseATRBoxSize = true
+		} else {
+			boxSize, err := strconv.ParseFloat(box, 64)
+			if err != nil {
+				return fmt.Errorf("invalid box size: %s", box)
+			}
+			c.BoxSize = boxSize
+		}
+	}
 
 	c.K = core.ReadQueryValueFloat64(rc, "k", 2.0)
 	c.Degree = core.ReadQueryValueInt(rc, "degree", 2)
@@ -96,12 +291,157 @@ func (c *Chart) Parse(rc *web.Ctx) error {
 	c.Limit = core.ReadQueryValueInt(rc, "limit", 32)
 	c.Offset = core.ReadQueryValueInt(rc, "offset", 0)
 
-	if c.UsePercentageDifferences {
+	// more than one comparison can't share a secondary axis, so they're all normalized to percent change.
+	if len(c.TickersCompare) > 1 {
+		c.UsePercentageDifferences = true
+	}
+
+	if c.UsePercentageDifferences || c.isDrawdown() {
 		c.YValueFormatter = chart.PercentValueFormatter
+	} else if c.isRatio() {
+		c.YValueFormatter = ratioValueFormatter
 	} else {
 		c.YValueFormatter = chart.FloatValueFormatter
 	}
 
+	joinPolicy, err := model.ParseJoinPolicy(core.ReadQueryValue(rc, "join", ""))
+	if err != nil {
+		return err
+	}
+	c.JoinPolicy = joinPolicy
+
+	interval, err := model.ParseBarInterval(core.ReadQueryValue(rc, "interval", ""))
+	if err != nil {
+		return err
+	}
+	c.Interval = interval
+	return nil
+}
+
+// parseStyle reads the style overrides, which are applied on top of the theme.
+func (c *Chart) parseStyle(rc *web.Ctx) error {
+	if value := core.ReadQueryValue(rc, "color", ""); len(value) > 0 {
+		colors, err := core.ParseHexColors(value)
+		if err != nil {
+			return err
+		}
+		c.Theme = c.Theme.WithSeriesColors(colors...)
+	}
+
+	var err error
+	if c.LineWidth, err = parseStyleFloat(rc, "line_width", chart.DefaultSeriesLineWidth); err != nil {
+		return err
+	}
+	if c.LineWidth <= 0 {
+		return errors.New("line_width must be positive")
+	}
+	if c.FillOpacity, err = parseStyleFloat(rc, "fill_opacity", defaultFillOpacity); err != nil {
+		return err
+	}
+	if c.FillOpacity < 0 || c.FillOpacity > 1 {
+		return errors.New("fill_opacity must be between 0 and 1")
+	}
+	if c.FontSize, err = parseStyleFloat(rc, "font_size", 0); err != nil {
+		return err
+	}
+	if c.FontSize < 0 {
+		return errors.New("font_size cannot be negative")
+	}
+
+	if c.GridDashArray, err = core.ParseDashArray(core.ReadQueryValue(rc, "grid_dash", "5,5")); err != nil {
+		return err
+	}
+	if value := core.ReadQueryValue(rc, "padding", ""); len(value) > 0 {
+		if c.Padding, err = core.ParsePadding(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTextSize sizes a text chart by the terminal columns and rows it fills.
+func (c *Chart) parseTextSize(rc *web.Ctx) error {
+	c.Columns = core.ReadQueryValueInt(rc, "cols", defaultTextColumns)
+	c.Rows = core.ReadQueryValueInt(rc, "rows", defaultTextRows)
+	if c.Columns < minTextColumns || c.Columns > maxTextColumns {
+		return fmt.Errorf("cols must be between %d and %d", minTextColumns, maxTextColumns)
+	}
+	if c.Rows < minTextRows || c.Rows > maxTextRows {
+		return fmt.Errorf("rows must be between %d and %d", minTextRows, maxTextRows)
+	}
+	c.Width, c.Height = c.Columns*core.TextCellWidth, c.Rows*core.TextCellHeight
+	return nil
+}
+
+// parseScale reads the repeatable `scale`, which is either the y axis scale (`linear` or `log`) or the pixel
+// density (`scale=2`), and the pixel density as a `dpi`, i.e. `scale=log&dpi=184`.
+func (c *Chart) parseScale(rc *web.Ctx) error {
+	c.UseLogScale, c.Scale = false, minScale
+	if dpi := core.ReadQueryValueFloat64(rc, "dpi", 0); dpi > 0 {
+		c.Scale = dpi / chart.DefaultDPI
+	}
+	for _, value := range core.ReadQueryValues(rc, "scale") {
+		if util.String.CaseInsensitiveEquals(value, "log") {
+			c.UseLogScale = true
+		} else if scale, err := strconv.ParseFloat(value, 64); err == nil {
+			c.Scale = scale
+		} else if !util.String.CaseInsensitiveEquals(value, "linear") {
+			return fmt.Errorf("invalid scale: %s", value)
+		}
+	}
+	if c.Scale < minScale || c.Scale > maxScale {
+		return fmt.Errorf("scale must be between %.0f and %.0f (a dpi of %.0f to %.0f)", minScale, maxScale, minScale*chart.DefaultDPI, maxScale*chart.DefaultDPI)
+	}
+	return nil
+}
+
+// validateSize bounds the size of the image, as the memory to draw it grows with its pixels; the pixels at the
+// chart's scale, for every frame of an animation. Text charts are sized by their columns and rows instead.
+func (c *Chart) validateSize() error {
+	if c.isTextFormat() {
+		return nil
+	}
+	if c.Width < minChartSize || c.Width > maxChartSize || c.Height < minChartSize || c.Height > maxChartSize {
+		return fmt.Errorf("width and height must be between %d and %d", minChartSize, maxChartSize)
+	}
+	pixels := float64(c.Width) * float64(c.Height)
+	if c.isPNGFormat() || c.isGIFFormat() {
+		pixels *= c.Scale * c.Scale
+	}
+	if c.isGIFFormat() {
+		pixels *= float64(c.Frames)
+	}
+	if pixels > maxChartPixels {
+		return errors.New("the image is too large to draw; reduce the width, height, scale or frames")
+	}
+	return nil
+}
+
+// parseReferences reads the repeatable reference lines (`hline=150:Target`), bands (`band=140-160`)
+// and shaded periods (`vband=2017-03-01..2017-03-15`).
+func (c *Chart) parseReferences(rc *web.Ctx) error {
+	c.ReferenceLines, c.ReferenceBands, c.ShadedPeriods = nil, nil, nil
+	for _, value := range core.ReadQueryValues(rc, "hline") {
+		line, err := core.ParseLine(value)
+		if err != nil {
+			return err
+		}
+		c.ReferenceLines = append(c.ReferenceLines, line)
+	}
+	for _, value := range core.ReadQueryValues(rc, "band") {
+		band, err := core.ParseBand(value)
+		if err != nil {
+			return err
+		}
+		c.ReferenceBands = append(c.ReferenceBands, band)
+	}
+	for _, value := range core.ReadQueryValues(rc, "vband") {
+		period, err := core.ParseDateRange(value, chartutil.Date.Eastern())
+		if err != nil {
+			return err
+		}
+		c.ShadedPeriods = append(c.ShadedPeriods, period)
+	}
 	return nil
 }
 
@@ -159,9 +499,70 @@ func (c *Chart) Validate() error {
 	if len(c.Ticker) == 0 {
 		return errors.New("caller did not specify a :ticker parameter, cannot continue")
 	}
-	if c.AddMACD && (c.hasCompare() && !c.UsePercentageDifferences) {
+	if err := validateFormat(c.Format, chartFormats); err != nil {
+		return err
+	}
+	switch c.Mode {
+	case chartModePrice, chartModeDrawdown, chartModeRatio, chartModeRenko, chartModePointAndFigure:
+	default:
+		return fmt.Errorf("invalid chart mode: %s", c.Mode)
+	}
+	if c.isBoxMode() {
+		if c.hasCompare() || c.UsePercentageDifferences {
+			return errors.New("renko and point & figure charts cannot be combined with comparisons or percentage differences")
+		}
+		if c.hasVolatility() || c.hasBenchmark() {
+			return errors.New("renko and point & figure charts cannot show volatility or beta")
+		}
+		if !c.UseATRBoxSize && c.BoxSize <= 0 {
+			return errors.New("box size must be positive, or `atr` to use the average true range")
+		}
+		if c.Reversal < 1 {
+			return errors.New("reversal must be at least 1 box")
+		}
+		if c.ShowEvents || len(c.ShadedPeriods) > 0 {
+			return errors.New("renko and point & figure charts cannot show events or shaded periods, as they aren't plotted over time")
+		}
+	}
+	if c.isRatio() {
+		if len(c.TickerVersus) == 0 {
+			return errors.New("ratio mode requires a `vs` ticker")
+		}
+		if c.hasCompare() || c.UsePercentageDifferences {
+			return errors.New("ratio mode cannot be combined with comparisons or percentage differences")
+		}
+	} else if len(c.TickerVersus) > 0 {
+		return errors.New("the `vs` ticker is only used in ratio mode")
+	}
+	if len(c.ReferenceLines)+len(c.ReferenceBands)+len(c.ShadedPeriods) > maxReferences {
+		return fmt.Errorf("cannot draw more than %d reference lines, bands and periods", maxReferences)
+	}
+	if len(c.TickersCompare) > maxCompareTickers {
+		return fmt.Errorf("cannot compare more than %d tickers", maxCompareTickers)
+	}
+	if c.AddMACD && (c.hasCompare() && c.compareOnSecondaryAxis()) {
 		return errors.New("cannot add both MACD histogram and use a secondary axis for comparison")
 	}
+	if c.UseLogScale && (c.UsePercentageDifferences || c.isDrawdown()) {
+		return errors.New("cannot use a log scale with percentage differences or drawdowns")
+	}
+	if c.VolatilityWindow < 0 || c.VolatilityWindow == 1 {
+		return errors.New("add_vol window must be at least 2 days")
+	}
+	if c.hasBenchmark() && c.BetaWindow < 2 {
+		return errors.New("beta_window must be at least 2 days")
+	}
+	if (c.hasVolatility() || c.hasBenchmark()) && c.ShouldUseDaySeries {
+		return errors.New("volatility and beta require daily prices; use a 3m or longer period")
+	}
+	if c.Interval != model.BarIntervalDaily {
+		if c.ShouldUseDaySeries {
+			return errors.New("weekly and monthly intervals require daily prices; use a 3m or longer period")
+		}
+		if c.hasVolatility() || c.hasBenchmark() {
+			return errors.New("volatility and beta are computed from daily returns; use a 1d interval")
+		}
+	}
 	if c.Start.IsZero() {
 		return errors.New("data period start time is unset, cannot continue")
 	}
@@ -174,10 +575,7 @@ func (c *Chart) Validate() error {
 
 // FetchTickers fetches the ticker information.
 func (c *Chart) FetchTickers() error {
-	tickers := []string{c.Ticker}
-	if c.hasCompare() {
-		tickers = []string{c.Ticker, c.TickerCompare}
-	}
+	tickers := c.getTickers()
 	quotes, err := google.GetCurrentPrices(tickers)
 	if err != nil {
 		return err
@@ -192,20 +590,164 @@ func (c *Chart) FetchTickers() error {
 	c.Ticker = strings.ToUpper(c.Ticker)
 	c.TickerInfo = &quotes[0]
 
-	if len(quotes) > 1 {
-		if quotes[1].IsZero() {
-			return fmt.Errorf("No stock information returned for: %s", strings.ToUpper(c.TickerCompare))
+	// getQuote returns the next quote, or an error if the quote service didn't return one for the ticker.
+	nextQuote := 1
+	getQuote := func(ticker string) (*equity.Quote, error) {
+		if len(quotes) <= nextQuote || quotes[nextQuote].IsZero() {
+			return nil, fmt.Errorf("No stock information returned for: %s", strings.ToUpper(ticker))
+		}
+		nextQuote++
+		return &quotes[nextQuote-1], nil
+	}
+
+	c.TickersCompareInfo = nil
+	for index, ticker := range c.TickersCompare {
+		quote, err := getQuote(ticker)
+		if err != nil {
+			return err
+		}
+		c.TickersCompare[index] = strings.ToUpper(ticker)
+		c.TickersCompareInfo = append(c.TickersCompareInfo, *quote)
+	}
+
+	if c.hasBenchmark() {
+		if c.TickerBenchmarkInfo, err = getQuote(c.TickerBenchmark); err != nil {
+			return err
+		}
+		c.TickerBenchmark = strings.ToUpper(c.TickerBenchmark)
+	}
+
+	if c.isRatio() {
+		if c.TickerVersusInfo, err = getQuote(c.TickerVersus); err != nil {
+			return err
+		}
+		c.TickerVersus = strings.ToUpper(c.TickerVersus)
+	}
+
+	// the company name is only needed for the default title.
+	if c.ShowTitle && len(c.Title) == 0 {
+		tickerEquity, err := model.GetEquityByTicker(c.Ticker)
+		if err != nil {
+			return err
+		}
+		if !tickerEquity.IsZero() {
+			c.TickerEquity = tickerEquity
 		}
-		c.TickerCompare = strings.ToUpper(c.TickerCompare)
-		c.TickerCompareInfo = &quotes[1]
 	}
 	return nil
 }
 
 // FetchPriceData fetches price data.
 func (c *Chart) FetchPriceData() error {
-	var useLivePricing, useHistoricalPricing bool
+	useLivePricing, useHistoricalPricing := c.getPricingSources()
+	data, err := GetEquityPricesByDateForTickers(c.getTickers(), c.Start, c.End, useLivePricing, useHistoricalPricing)
+	if err != nil {
+		return err
+	}
+
+	for index := range data {
+		data[index] = model.EquityPrices(data[index]).Resample(c.Interval)
+	}
+
+	c.tickerData = data[0]
+	next := 1
+	c.tickersCompareData = data[next : next+len(c.TickersCompare)]
+	next += len(c.TickersCompare)
+	if c.hasBenchmark() {
+		c.tickerBenchmarkData = data[next]
+		next++
+	}
+	if c.isRatio() {
+		c.tickerVersusData = data[next]
+	}
+
+	if c.ShowEvents {
+		events, err := model.GetEquityEventsByDate(c.Ticker, c.Start, c.End)
+		if err != nil {
+			return err
+		}
+		c.events = events
+	}
+	return nil
+}
+
+// ValidatePriceData applies the validation rules that depend on the fetched prices; a box size so small the
+// price range spans more than `core.MaxBoxLevels` boxes would take too long to chart.
+func (c *Chart) ValidatePriceData() error {
+	if c.isBoxMode() {
+		boxSize := c.getBoxSize()
+		if model.EquityPrices(c.tickerData).BoxLevels(boxSize) > core.MaxBoxLevels {
+			return fmt.Errorf("the box size %s is too small for the price range, which can span at most %d boxes; try a larger box size", formatFloat(boxSize), core.MaxBoxLevels)
+		}
+	}
+	return nil
+}
+
+// CacheKey returns the chart's parsed parameters and the dates it spans, so equivalent requests (i.e. with the
+// query in a different order, or with defaults spelled out) share rendered images, and a chart of a trailing
+// timeframe is drawn again as its window moves, whether or not there are new prices.
+func (c *Chart) CacheKey() string {
+	key := bytes.NewBuffer(nil)
+	fmt.Fprintf(key, "%s/%s %s..%s", strings.ToUpper(c.Ticker), strings.ToLower(c.ChartTimeframe), c.Start.Format(chart.DefaultDateFormat), c.End.Format(chart.DefaultDateFormat))
+	value := reflect.ValueOf(c).Elem()
+	for index := 0; index < value.NumField(); index++ {
+		if field := value.Type().Field(index); len(field.Tag.Get("query")) > 0 {
+			fmt.Fprintf(key, " %s=%#v", field.Name, value.Field(index).Interface())
+		}
+	}
+	// the page links to the chart in other timeframes with the query as it was requested.
+	if c.isHTMLFormat() {
+		fmt.Fprintf(key, " %s", c.query.Encode())
+	}
+	return key.String()
+}
+
+// CacheTTL returns how long a rendered chart is served before checking for new data; intraday timeframes
+// change by the minute, the longer ones once a day at most.
+func (c *Chart) CacheTTL() time.Duration {
+	switch strings.ToLower(c.ChartTimeframe) {
+	case "5y":
+		return 12 * time.Hour
+	case "2y":
+		return 6 * time.Hour
+	case "ltm", "6m", "3m", "2m":
+		return time.Hour
+	case "1m":
+		return 15 * time.Minute
+	case "10d", "1wk", "3d":
+		return 5 * time.Minute
+	}
+	return time.Minute
+}
+
+// ETag returns the entity tag of the chart drawn from the fetched data, for a cache key. Besides the latest price
+// it covers the quote in the title header and the events drawn, which change without new prices.
+func (c *Chart) ETag(key string) string {
+	parts := []string{key, c.LastModified().Format(time.RFC3339Nano)}
+	if c.ShowTitle && c.TickerInfo != nil {
+		parts = append(parts, fmt.Sprintf("quote %s %v %v %v", c.TickerInfo.Timestamp.Format(time.RFC3339Nano), c.TickerInfo.Last, c.TickerInfo.Change, c.TickerInfo.ChangePCT))
+	}
+	if c.ShowEvents {
+		for _, event := range c.events {
+			parts = append(parts, fmt.Sprintf("event %d %s %s %q %v", event.ID, event.Kind, event.TimestampUTC.Format(time.RFC3339Nano), event.Label, event.Value))
+		}
+	}
+	return core.ETag(parts...)
+}
+
+// LastModified returns the latest timestamp of the fetched prices of any of the chart's tickers.
+func (c *Chart) LastModified() time.Time {
+	var lastModified time.Time
+	for _, data := range append([][]model.EquityPrice{c.tickerData, c.tickerBenchmarkData, c.tickerVersusData}, c.tickersCompareData...) {
+		if last := model.EquityPrices(data).Last(); last != nil && last.TimestampUTC.After(lastModified) {
+			lastModified = last.TimestampUTC
+		}
+	}
+	return lastModified
+}
 
+// getPricingSources returns if the timeframe uses live (intraday) and historical (daily) prices.
+func (c *Chart) getPricingSources() (useLivePricing, useHistoricalPricing bool) {
 	switch strings.ToLower(c.ChartTimeframe) {
 	case "5y", "2y", "ltm", "6m", "3m":
 		useLivePricing = false
@@ -217,108 +759,523 @@ func (c *Chart) FetchPriceData() error {
 		useLivePricing = true
 		useHistoricalPricing = false
 	}
+	return
+}
+
+// getTickers returns every ticker the chart needs data for, in the order they're fetched:
+// the ticker, the comparisons, the beta benchmark and the ratio benchmark.
+func (c *Chart) getTickers() []string {
+	tickers := append([]string{c.Ticker}, c.TickersCompare...)
+	if c.hasBenchmark() {
+		tickers = append(tickers, c.TickerBenchmark)
+	}
+	if c.isRatio() {
+		tickers = append(tickers, c.TickerVersus)
+	}
+	return tickers
+}
 
-	data, err := GetEquityPricesByDate(c.Ticker, c.Start, c.End, useLivePricing, useHistoricalPricing)
+// CreateImage creates the full image for the parameters; the price chart stacked above any indicator sub-panels.
+func (c *Chart) CreateImage() (core.Renderable, error) {
+	graph, err := c.CreateChart()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if c.isHTMLFormat() {
+		return c.createPage(graph), nil
 	}
-	c.tickerData = data
+	if c.isGIFFormat() {
+		animation, err := c.createAnimation()
+		if err != nil {
+			return nil, err
+		}
+		return c.scaled(animation), nil
+	}
+	return c.scaled(c.layout(graph)), nil
+}
+
+// scaled draws png and gif images at the chart's pixel density; svg and pdf are drawn at the logical size,
+// they scale on their own.
+func (c *Chart) scaled(image core.Renderable) core.Renderable {
+	if c.Scale <= minScale || !(c.isPNGFormat() || c.isGIFFormat()) {
+		return image
+	}
+	return core.Scaled{Renderable: image, Scale: c.Scale}
+}
+
+// createAnimation creates the frames of the chart growing across the timeframe, each drawn from the data up to
+// that frame against the full time axis. Early frames that can't be drawn (i.e. before a renko chart has its first
+// brick) are skipped.
+func (c *Chart) createAnimation() (core.Renderable, error) {
+	tickerData, tickersCompareData := c.tickerData, c.tickersCompareData
+	tickerBenchmarkData, tickerVersusData, events := c.tickerBenchmarkData, c.tickerVersusData, c.events
+	defer func() {
+		c.tickerData, c.tickersCompareData = tickerData, tickersCompareData
+		c.tickerBenchmarkData, c.tickerVersusData, c.events = tickerBenchmarkData, tickerVersusData, events
+		c.xrangeMax = time.Time{}
+	}()
+	c.xrangeMax = model.EquityPrices(tickerData).Last().TimestampUTC
+
+	var frames []core.Renderable
+	for frame := 1; frame <= c.Frames; frame++ {
+		count := len(tickerData) * frame / c.Frames
+		if count < 2 && len(tickerData) >= 2 {
+			count = 2
+		} else if count < 1 {
+			count = 1
+		}
+		cutoff := tickerData[count-1].TimestampUTC
+
+		c.tickerData = tickerData[:count]
+		c.tickersCompareData = make([][]model.EquityPrice, len(tickersCompareData))
+		for index, data := range tickersCompareData {
+			c.tickersCompareData[index] = model.EquityPrices(data).Through(cutoff)
+		}
+		c.tickerBenchmarkData = model.EquityPrices(tickerBenchmarkData).Through(cutoff)
+		c.tickerVersusData = model.EquityPrices(tickerVersusData).Through(cutoff)
+		c.events = nil
+		for _, event := range events {
+			if !event.TimestampUTC.After(cutoff) {
+				c.events = append(c.events, event)
+			}
+		}
 
-	if c.hasCompare() {
-		compareData, err := GetEquityPricesByDate(c.TickerCompare, c.Start, c.End, useLivePricing, useHistoricalPricing)
+		graph, err := c.CreateChart()
 		if err != nil {
-			return err
+			if frame < c.Frames {
+				continue
+			}
+			return nil, err
+		}
+		frames = append(frames, c.layout(graph))
+	}
+	return core.Animation{Frames: frames}, nil
+}
+
+// createPage creates the html page for the chart, which embeds the image and the values under the cursor.
+func (c *Chart) createPage(graph chart.Chart) core.Renderable {
+	// intraday timestamps are labeled with the time, as they are on the header.
+	format := "Jan 2, 2006"
+	if c.ShouldUseDaySeries {
+		format = "Jan 2, 3:04 PM MST"
+	}
+	xvf := func(v interface{}) string {
+		if typed, isTyped := v.(float64); isTyped {
+			return chartutil.Time.FromFloat64(typed).In(chartutil.Date.Eastern()).Format(format)
+		}
+		return ""
+	}
+	if c.isBoxMode() {
+		labels := c.getBoxLabels(c.boxes)
+		xvf = func(v interface{}) string {
+			if index, isIndex := v.(float64); isIndex && int(index) < len(labels) {
+				return labels[int(index)]
+			}
+			return ""
 		}
-		c.tickerCompareData = compareData
 	}
 
-	return nil
+	table := core.NewSeriesTable("", xvf, c.getSeries()...)
+	positions := &core.Positions{}
+	xvalues := make([]float64, len(table.Rows))
+	for index, row := range table.Rows {
+		xvalues[index] = row.XValue
+	}
+	graph.Series = append(graph.Series, core.PositionSeries{XValues: xvalues, Positions: positions})
+
+	title := c.Title
+	if len(title) == 0 {
+		title = c.Ticker
+	}
+	return core.Page{
+		Title:  title,
+		Width:  c.Width,
+		Height: c.Height,
+		Image:  c.layout(graph),
+		Links:  c.getTimeframeLinks(),
+		Style: chart.Style{
+			FillColor:   c.Theme.Background,
+			FontColor:   c.Theme.Text,
+			StrokeColor: c.Theme.Axis,
+		},
+		Table:           table,
+		YValueFormatter: c.YValueFormatter,
+		Positions:       positions,
+		Top:             c.getHeaderHeight(),
+	}
+}
+
+// getTimeframeLinks returns links to the chart, as it was requested, in each timeframe.
+func (c *Chart) getTimeframeLinks() []core.PageLink {
+	links := make([]core.PageLink, len(chartTimeframes))
+	for index, timeframe := range chartTimeframes {
+		links[index] = core.PageLink{
+			Label:  strings.ToUpper(timeframe),
+			URL:    fmt.Sprintf("/stock/chart/%s/%s?%s", url.PathEscape(c.Ticker), timeframe, c.query.Encode()),
+			Active: strings.EqualFold(timeframe, c.ChartTimeframe),
+		}
+	}
+	return links
+}
+
+// layout stacks the price chart above any indicator sub-panels, under the title.
+func (c *Chart) layout(graph chart.Chart) core.Renderable {
+	subPanels := c.getSubPanels()
+	if len(subPanels) == 0 && !c.ShowTitle {
+		return graph
+	}
+	if len(subPanels) > 0 {
+		subPanels[len(subPanels)-1].XAxis.Style.Show = c.ShowAxes
+	}
+
+	layout := core.Layout{
+		Width:      c.Width,
+		Height:     c.Height,
+		Background: chart.Style{FillColor: c.Theme.Background},
+	}
+	if c.ShowTitle {
+		layout.Panels = append(layout.Panels, core.Panel{Renderable: c.getHeader()})
+	}
+	layout.Panels = append(layout.Panels, core.Panel{Top: c.getHeaderHeight(), Renderable: graph})
+	top := c.getHeaderHeight() + graph.Height
+	for _, subPanel := range subPanels {
+		layout.Panels = append(layout.Panels, core.Panel{Top: top, Renderable: subPanel})
+		top += subPanel.Height
+	}
+	return layout
+}
+
+// CreateTable creates the values of every series the chart plots, sub-panels included, aligned by timestamp
+// (or by box, for renko and point & figure charts).
+func (c *Chart) CreateTable() (core.SeriesTable, error) {
+	if _, err := c.CreateChart(); err != nil {
+		return core.SeriesTable{}, err
+	}
+
+	series := c.getSeries()
+	for _, panel := range c.getSubPanels() {
+		series = append(series, panel.Series...)
+	}
+
+	if c.isBoxMode() {
+		labels := c.getBoxLabels(c.boxes)
+		return core.NewSeriesTable("date", func(v interface{}) string {
+			if index, isIndex := v.(float64); isIndex && int(index) < len(labels) {
+				return labels[int(index)]
+			}
+			return ""
+		}, series...), nil
+	}
+	return core.NewSeriesTable("timestamp", func(v interface{}) string {
+		if typed, isTyped := v.(float64); isTyped {
+			return chartutil.Time.FromFloat64(typed).UTC().Format(time.RFC3339)
+		}
+		return ""
+	}, series...), nil
+}
+
+// CreateVegaLite creates a vega-lite spec of the chart for rendering on the client; the series are computed
+// as they are for images, and the sub-panels are stacked under the price chart.
+func (c *Chart) CreateVegaLite() (core.VegaLite, error) {
+	graph, err := c.CreateChart()
+	if err != nil {
+		return core.VegaLite{}, err
+	}
+
+	xtype, xvalue := core.VegaLiteTemporal, func(x float64) interface{} {
+		// eastern wall clock times on a utc scale, so the axis reads as it does on the images.
+		wallClock := chartutil.Time.FromFloat64(x).In(chartutil.Date.Eastern())
+		return wallClock.Format("2006-01-02T15:04:05Z")
+	}
+	xformat := getD3Format(c.XValueFormatter, getD3Format(chart.TimeValueFormatter, ""))
+	if c.isBoxMode() {
+		xtype, xvalue, xformat = core.VegaLiteOrdinal, func(x float64) interface{} { return int(x) }, ""
+	}
+
+	panels := []core.VegaLitePanel{{
+		Height:      graph.Height,
+		XType:       xtype,
+		XValue:      xvalue,
+		XFormat:     xformat,
+		YTitle:      graph.YAxis.Name,
+		YFormat:     getD3Format(c.YValueFormatter, ""),
+		UseLogScale: c.UseLogScale,
+		ShowGrid:    c.ShowGrid,
+		ShowLegend:  c.ShowLegend,
+		Series:      c.getSeries(),
+	}}
+	for _, subPanel := range c.getSubPanels() {
+		panels = append(panels, core.VegaLitePanel{
+			Height:     subPanel.Height,
+			XType:      xtype,
+			XValue:     xvalue,
+			XFormat:    xformat,
+			YTitle:     subPanel.YAxis.Name,
+			YFormat:    getD3Format(subPanel.YAxis.ValueFormatter, ""),
+			ShowGrid:   c.ShowGrid,
+			ShowLegend: c.ShowLegend,
+			Series:     subPanel.Series,
+		})
+	}
+
+	title := c.Title
+	if len(title) == 0 {
+		title = c.Ticker
+	}
+	return core.NewVegaLite(title, c.Width, panels...), nil
 }
 
 // CreateChart creates a chart object for the parameters.
 func (c *Chart) CreateChart() (chart.Chart, error) {
 	var xrange chart.Range
-	if len(c.tickerData) > 0 {
+	tickPosition := chart.TickPositionBetweenTicks
+	if len(c.tickerData) > 0 && c.isBoxMode() {
+		boxes, err := c.getBoxes()
+		if err != nil {
+			return chart.Chart{}, err
+		}
+		c.boxes = boxes
+		xrange = core.NewIndexRange(c.getBoxLabels(boxes))
+		tickPosition = chart.TickPositionUnderTick
+	} else if len(c.tickerData) > 0 {
 		switch strings.ToLower(c.ChartTimeframe) {
 		case "ltm", "6m", "3m":
 			xrange = &chart.ContinuousRange{}
 		case "1m", "1wk", "10d", "3d", "1d":
 			xrange = &chart.MarketHoursRange{
 				Min:             model.EquityPrices(c.tickerData).First().TimestampUTC.In(chartutil.Date.Eastern()),
-				Max:             model.EquityPrices(c.tickerData).Last().TimestampUTC.In(chartutil.Date.Eastern()),
+				Max:             c.getXRangeMax().In(chartutil.Date.Eastern()),
 				MarketOpen:      chartutil.NYSEOpen(),
 				MarketClose:     chartutil.NYSEClose(),
 				HolidayProvider: chartutil.Date.IsNYSEHoliday,
 			}
 		}
+		// the frames of an animation are drawn against the full time axis.
+		if _, isMarketHours := xrange.(*chart.MarketHoursRange); !isMarketHours && !c.xrangeMax.IsZero() {
+			xrange = &chart.ContinuousRange{
+				Min: chartutil.Time.ToFloat64(model.EquityPrices(c.tickerData).First().TimestampUTC),
+				Max: chartutil.Time.ToFloat64(c.xrangeMax),
+			}
+		}
 	} else {
 		return chart.Chart{}, errors.New("no data")
 	}
 
 	yname := "Price USD"
-	if c.UsePercentageDifferences {
+	if c.isDrawdown() {
+		yname = "% Drawdown"
+	} else if c.isRatio() {
+		yname = fmt.Sprintf("Ratio vs. %s", c.TickerVersus)
+	} else if c.UsePercentageDifferences {
 		yname = "% Change"
+	} else if c.UseLogScale {
+		yname = "Price USD (log)"
 	}
 
 	graph := chart.Chart{
-		Width:  c.Width,
-		Height: c.Height,
+		Width:        c.Width,
+		Height:       c.Height - c.getHeaderHeight() - c.subPanelCount()*c.getSubPanelHeight(),
+		ColorPalette: c.Theme,
+		Background:   chart.Style{Padding: c.Padding},
 		XAxis: chart.XAxis{
 			ValueFormatter: c.XValueFormatter,
 			Style: chart.Style{
-				Show: c.ShowAxes,
+				Show:     c.ShowAxes && c.subPanelCount() == 0,
+				FontSize: c.FontSize,
 			},
-			TickPosition: chart.TickPositionBetweenTicks,
+			TickPosition: tickPosition,
 			GridMajorStyle: chart.Style{
 				Show:            c.ShowGrid,
-				StrokeColor:     drawing.ColorFromHex("000"),
+				StrokeColor:     c.Theme.Grid,
 				StrokeWidth:     1.0,
-				StrokeDashArray: []float64{5.0, 5.0},
+				StrokeDashArray: c.GridDashArray,
 			},
 			GridMinorStyle: chart.Style{
 				Show:            c.ShowGrid,
-				StrokeColor:     drawing.ColorFromHex("000"),
+				StrokeColor:     c.Theme.Grid,
 				StrokeWidth:     1.0,
-				StrokeDashArray: []float64{5.0, 5.0},
+				StrokeDashArray: c.GridDashArray,
 			},
 			Range: xrange,
 		},
 		YAxis: chart.YAxis{
 			Name:      yname,
-			NameStyle: chart.StyleShow(),
+			NameStyle: chart.Style{Show: true, FontSize: c.FontSize},
 			Zero: chart.GridLine{
 				Style: chart.Style{
-					Show:            true,
-					StrokeColor:     drawing.ColorFromHex("ccccccc"),
+					Show:            !c.UseLogScale,
+					StrokeColor:     c.Theme.ZeroLine,
 					StrokeWidth:     1.0,
 					StrokeDashArray: []float64{5, 5},
 				},
 			},
 			ValueFormatter: c.YValueFormatter,
 			Style: chart.Style{
-				Show: c.ShowAxes,
+				Show:     c.ShowAxes,
+				FontSize: c.FontSize,
 			},
+			Range: c.getPriceRange(),
 		},
 		YAxisSecondary: chart.YAxis{
 			ValueFormatter: c.YValueFormatter,
 			Style: chart.Style{
-				Show: c.showSecondaryAxis(),
+				Show:     c.showSecondaryAxis(),
+				FontSize: c.FontSize,
 			},
+			Range: c.getSecondaryRange(),
 		},
-		Series: c.getSeries(),
+	}
+	references := c.getReferenceSeries()
+	graph.Series = append(references, c.getSeries()...)
+	if len(c.events) > 0 {
+		graph.Series = append(graph.Series, c.getEventSeries())
 	}
 	if c.ShowLegend {
-		graph.Elements = []chart.Renderable{
-			chart.Legend(&graph, chart.Style{
-				FontSize: 8.0,
-			}),
-		}
+		// the reference lines, bands and periods are labeled in place, so they're left out of the legend.
+		legendGraph := graph
+		legendGraph.Series = graph.Series[len(references):]
+		graph.Elements = []chart.Renderable{c.getLegend(&legendGraph)}
 	}
 	return graph, nil
 }
 
+// getHeader returns the title banner; the company name and ticker, the exchange and quote time, and the last price and change.
+func (c *Chart) getHeader() core.Header {
+	header := core.Header{
+		Width:      c.Width,
+		Height:     c.getHeaderHeight(),
+		Title:      c.Title,
+		Style:      chart.Style{FontColor: c.Theme.Text},
+		Background: chart.Style{FillColor: c.Theme.Background},
+	}
+	if len(header.Title) == 0 {
+		header.Title = c.Ticker
+		if c.TickerEquity != nil && len(c.TickerEquity.Name) > 0 {
+			header.Title = fmt.Sprintf("%s (%s)", c.TickerEquity.Name, c.Ticker)
+		}
+	}
+
+	var subtitle []string
+	if c.TickerInfo != nil && len(c.TickerInfo.Exchange) > 0 {
+		subtitle = append(subtitle, c.TickerInfo.Exchange)
+	} else if c.TickerEquity != nil && len(c.TickerEquity.Exchange) > 0 {
+		subtitle = append(subtitle, c.TickerEquity.Exchange)
+	}
+	if c.TickerInfo != nil {
+		subtitle = append(subtitle, c.TickerInfo.Timestamp.In(chartutil.Date.Eastern()).Format("Jan 2, 3:04 PM MST"))
+		header.Value = chart.FloatValueFormatter(c.TickerInfo.Last)
+		header.Change = fmt.Sprintf("%+.2f (%+.2f%%)", c.TickerInfo.Change, c.TickerInfo.ChangePCT)
+		header.ChangeColor = c.Theme.GetUpDownColor(c.TickerInfo.Change >= 0)
+	}
+	header.Subtitle = strings.Join(subtitle, " · ")
+	return header
+}
+
+func (c *Chart) getSubPanels() []chart.Chart {
+	var panels []chart.Chart
+	if c.hasVolatility() {
+		xvalues, yvalues := model.EquityPrices(c.tickerData).RollingVolatility(c.VolatilityWindow)
+		vol := chart.TimeSeries{
+			Name: fmt.Sprintf("%s %dd Volatility", c.Ticker, c.VolatilityWindow),
+			Style: chart.Style{
+				Show:        true,
+				StrokeColor: c.Theme.GetSeriesColor(0),
+				StrokeWidth: c.LineWidth,
+			},
+			XValues: xvalues,
+			YValues: yvalues,
+		}
+		panels = append(panels, c.createSubPanel("Ann. Vol.", chart.PercentValueFormatter, vol))
+	}
+
+	if c.hasBenchmark() {
+		xvalues, beta, correlation := model.EquityPrices(c.tickerData).RollingBeta(c.tickerBenchmarkData, c.BetaWindow)
+		betaSeries := chart.TimeSeries{
+			Name: fmt.Sprintf("%s %dd Beta vs. %s", c.Ticker, c.BetaWindow, c.TickerBenchmark),
+			Style: chart.Style{
+				Show:        true,
+				StrokeColor: c.Theme.GetSeriesColor(0),
+				StrokeWidth: c.LineWidth,
+			},
+			XValues: xvalues,
+			YValues: beta,
+		}
+		correlationSeries := chart.TimeSeries{
+			Name: fmt.Sprintf("%s %dd Corr. vs. %s", c.Ticker, c.BetaWindow, c.TickerBenchmark),
+			Style: chart.Style{
+				Show:            true,
+				StrokeColor:     c.Theme.GetSeriesColor(1),
+				StrokeWidth:     c.LineWidth,
+				StrokeDashArray: []float64{5.0, 5.0},
+			},
+			XValues: xvalues,
+			YValues: correlation,
+		}
+		panels = append(panels, c.createSubPanel("Beta / Corr.", chart.FloatValueFormatter, betaSeries, correlationSeries))
+	}
+	return panels
+}
+
+// createSubPanel creates an indicator chart that shares the price chart's width and time range.
+func (c *Chart) createSubPanel(yname string, yvf chart.ValueFormatter, series ...chart.TimeSeries) chart.Chart {
+	first := model.EquityPrices(c.tickerData).First()
+
+	panel := chart.Chart{
+		Width:        c.Width,
+		Height:       c.getSubPanelHeight(),
+		ColorPalette: c.Theme,
+		Background:   chart.Style{Padding: c.Padding},
+		XAxis: chart.XAxis{
+			ValueFormatter: c.XValueFormatter,
+			Style:          chart.Style{FontSize: c.FontSize},
+			TickPosition:   chart.TickPositionBetweenTicks,
+			Range: &chart.ContinuousRange{
+				Min: chartutil.Time.ToFloat64(first.TimestampUTC),
+				Max: chartutil.Time.ToFloat64(c.getXRangeMax()),
+			},
+		},
+		YAxis: chart.YAxis{
+			Name:           yname,
+			NameStyle:      chart.Style{Show: true, FontSize: c.FontSize},
+			ValueFormatter: yvf,
+			Style: chart.Style{
+				Show:     c.ShowAxes,
+				FontSize: c.FontSize,
+			},
+		},
+	}
+
+	for _, s := range series {
+		panel.Series = append(panel.Series, s)
+		if c.ShowLastValue && len(s.YValues) > 0 {
+			panel.Series = append(panel.Series, chart.AnnotationSeries{
+				Name:  fmt.Sprintf("%s - Last Value", s.Name),
+				Style: c.getAnnotationStyle(s.Style),
+				Annotations: []chart.Value2{
+					{XValue: chartutil.Time.ToFloat64(s.XValues[len(s.XValues)-1]), YValue: s.YValues[len(s.YValues)-1], Label: yvf(s.YValues[len(s.YValues)-1])},
+				},
+			})
+		}
+	}
+
+	if c.ShowLegend {
+		panel.Elements = []chart.Renderable{c.getLegend(&panel)}
+	}
+	return panel
+}
+
 func (c *Chart) getSeries() []chart.Series {
-	t0series := c.getPriceSeries(c.Ticker, c.tickerData)
+	if c.isDrawdown() {
+		return c.getDrawdownModeSeries()
+	}
+	if c.isRatio() {
+		return c.getRatioModeSeries()
+	}
+	if c.isBoxMode() {
+		return c.getBoxModeSeries()
+	}
+
+	t0series := c.getPriceSeries(c.Ticker, c.tickerData, 0)
 	series := []chart.Series{}
 
 	if c.AddBollingerBands {
@@ -335,11 +1292,11 @@ func (c *Chart) getSeries() []chart.Series {
 		series = append(series, c.getLastValueSeries(c.Ticker, t0series))
 	}
 
-	if c.hasCompare() {
-		t1series := c.getPriceSeries(c.TickerCompare, c.tickerCompareData)
-		series = append(series, t1series)
+	for index, ticker := range c.TickersCompare {
+		tnseries := c.getPriceSeries(ticker, c.tickersCompareData[index], index+1)
+		series = append(series, tnseries)
 		if c.ShowLastValue {
-			series = append(series, c.getLastValueSeries(c.TickerCompare, t1series))
+			series = append(series, c.getLastValueSeries(ticker, tnseries))
 		}
 	}
 
@@ -389,7 +1346,9 @@ func (c *Chart) getSeries() []chart.Series {
 	return series
 }
 
-func (c *Chart) getPriceSeries(ticker string, data []model.EquityPrice) chart.TimeSeries {
+// getPriceSeries returns the price series for a ticker; index 0 is the primary ticker and
+// the comparison tickers follow in order.
+func (c *Chart) getPriceSeries(ticker string, data []model.EquityPrice, index int) chart.TimeSeries {
 	var xvalues []time.Time
 	var yvalues []float64
 	yaxis := chart.YAxisPrimary
@@ -399,12 +1358,8 @@ func (c *Chart) getPriceSeries(ticker string, data []model.EquityPrice) chart.Ti
 	} else {
 		xvalues, yvalues = model.EquityPrices(data).Prices()
 	}
-	index := 0
-	if util.String.CaseInsensitiveEquals(ticker, c.TickerCompare) {
-		index = 1
-		if !c.UsePercentageDifferences {
-			yaxis = chart.YAxisSecondary
-		}
+	if index > 0 && c.compareOnSecondaryAxis() {
+		yaxis = chart.YAxisSecondary
 	}
 	stroke, fill := c.getPriceSeriesColors(index)
 	return chart.TimeSeries{
@@ -414,10 +1369,270 @@ func (c *Chart) getPriceSeries(ticker string, data []model.EquityPrice) chart.Ti
 			Show:        true,
 			StrokeColor: stroke,
 			FillColor:   fill,
+			StrokeWidth: c.LineWidth,
+		},
+		XValues: xvalues,
+		YValues: yvalues,
+	}
+}
+
+func (c *Chart) getDrawdownModeSeries() []chart.Series {
+	t0series := c.getDrawdownSeries(c.Ticker, c.tickerData, 0)
+	series := []chart.Series{t0series}
+	if c.ShowLastValue {
+		series = append(series, c.getLastValueSeries(c.Ticker, t0series))
+	}
+
+	for index, ticker := range c.TickersCompare {
+		tnseries := c.getDrawdownSeries(ticker, c.tickersCompareData[index], index+1)
+		series = append(series, tnseries)
+		if c.ShowLastValue {
+			series = append(series, c.getLastValueSeries(ticker, tnseries))
+		}
+	}
+
+	if maxDrawdown := model.EquityPrices(c.tickerData).MaxDrawdown(); !maxDrawdown.IsZero() {
+		series = append(series, c.getMaxDrawdownSeries(c.Ticker, maxDrawdown, t0series.Style))
+	}
+	return series
+}
+
+func (c *Chart) getRatioModeSeries() []chart.Series {
+	xvalues, yvalues := model.EquityPrices(c.tickerData).Ratio(c.tickerVersusData, c.JoinPolicy)
+	stroke, fill := c.getPriceSeriesColors(0)
+	name := fmt.Sprintf("%s / %s", c.Ticker, c.TickerVersus)
+	ratio := chart.TimeSeries{
+		Name: name,
+		Style: chart.Style{
+			Show:        true,
+			StrokeColor: stroke,
+			FillColor:   fill,
+			StrokeWidth: c.LineWidth,
 		},
 		XValues: xvalues,
 		YValues: yvalues,
 	}
+
+	series := []chart.Series{ratio}
+	if c.ShowLastValue {
+		series = append(series, c.getLastValueSeries(name, ratio))
+	}
+	if c.AddSimpleMovingAverage {
+		sma := c.getSMASeries(name, ratio)
+		series = append(series, sma)
+		if c.ShowLastValue {
+			series = append(series, c.getLastValueSeries(name, sma))
+		}
+	}
+	return series
+}
+
+// getBoxes returns the renko bricks or point & figure columns for the ticker.
+func (c *Chart) getBoxes() ([]model.Box, error) {
+	boxSize := c.getBoxSize()
+	if boxSize <= 0 {
+		return nil, errors.New("cannot derive a box size from the price data")
+	}
+
+	var boxes []model.Box
+	if c.Mode == chartModeRenko {
+		boxes = model.EquityPrices(c.tickerData).Renko(boxSize)
+	} else {
+		boxes = model.EquityPrices(c.tickerData).PointAndFigure(boxSize, c.Reversal)
+	}
+	if len(boxes) == 0 {
+		return nil, fmt.Errorf("the price did not move a full box of %s; try a smaller box size", c.YValueFormatter(boxSize))
+	}
+	return boxes, nil
+}
+
+// getBoxSize returns the fixed box size, or the average true range of the ticker.
+func (c *Chart) getBoxSize() float64 {
+	if c.UseATRBoxSize {
+		return model.EquityPrices(c.tickerData).AverageTrueRange(defaultATRPeriod)
+	}
+	return c.BoxSize
+}
+
+// getBoxLabels labels each box with the date it was last extended, as boxes are plotted in order rather than over time.
+func (c *Chart) getBoxLabels(boxes []model.Box) []string {
+	xvf := c.XValueFormatter
+	if xvf == nil {
+		xvf = chart.TimeDateValueFormatter
+	}
+	labels := make([]string, len(boxes))
+	for index, box := range boxes {
+		labels[index] = xvf(box.End.In(chartutil.Date.Eastern()))
+	}
+	return labels
+}
+
+func (c *Chart) getBoxModeSeries() []chart.Series {
+	boxSize := c.getBoxSize()
+	boxValues := make([]core.BoxValue, len(c.boxes))
+	for index, box := range c.boxes {
+		boxValues[index] = core.BoxValue{Low: box.Low, High: box.High, IsUp: box.IsUp}
+	}
+
+	var series chart.Series
+	var name string
+	if c.Mode == chartModeRenko {
+		name = fmt.Sprintf("%s Renko (%s box)", c.Ticker, c.YValueFormatter(boxSize))
+		series = &core.RenkoSeries{
+			Name:      name,
+			Style:     chart.StyleShow(),
+			BoxValues: boxValues,
+			UpColor:   c.Theme.Up,
+			DownColor: c.Theme.Down,
+		}
+	} else {
+		name = fmt.Sprintf("%s P&F (%s x %d)", c.Ticker, c.YValueFormatter(boxSize), c.Reversal)
+		series = &core.PointAndFigureSeries{
+			Name:      name,
+			Style:     chart.StyleShow(),
+			BoxSize:   boxSize,
+			BoxValues: boxValues,
+			UpColor:   c.Theme.Up,
+			DownColor: c.Theme.Down,
+		}
+	}
+
+	boxSeries := []chart.Series{series}
+	if c.ShowLastValue && len(c.boxes) > 0 {
+		last := c.boxes[len(c.boxes)-1]
+		style := c.getAnnotationStyle(chart.Style{
+			Show:        true,
+			StrokeColor: c.Theme.GetUpDownColor(last.IsUp),
+		})
+		labelText := c.YValueFormatter(last.Last())
+		if !c.ShowLegend {
+			labelText = c.Ticker + " " + labelText
+		}
+		boxSeries = append(boxSeries, chart.AnnotationSeries{
+			Name:  fmt.Sprintf("%s - Last Value", name),
+			Style: style,
+			Annotations: []chart.Value2{
+				{XValue: float64(len(c.boxes) - 1), YValue: last.Last(), Label: labelText},
+			},
+		})
+	}
+	return boxSeries
+}
+
+// getReferenceSeries returns the shaded periods, bands and reference lines, which are drawn beneath the prices.
+func (c *Chart) getReferenceSeries() []chart.Series {
+	var series []chart.Series
+	if len(c.ShadedPeriods) > 0 {
+		series = append(series, core.PeriodSeries{
+			Name: "Periods",
+			Style: chart.Style{
+				Show:      true,
+				FillColor: c.Theme.Axis.WithAlpha(24),
+				FontColor: c.Theme.Text,
+				FontSize:  c.FontSize,
+			},
+			Periods: c.ShadedPeriods,
+		})
+	}
+
+	var levels []core.Level
+	levels = append(levels, c.ReferenceBands...)
+	for _, line := range c.ReferenceLines {
+		if len(line.Label) > 0 {
+			line.Label = fmt.Sprintf("%s (%s)", line.Label, c.YValueFormatter(line.Low))
+		} else {
+			line.Label = c.YValueFormatter(line.Low)
+		}
+		levels = append(levels, line)
+	}
+	if len(levels) > 0 {
+		level := core.LevelSeries{
+			Name: "Levels",
+			Style: chart.Style{
+				Show:        true,
+				StrokeColor: c.Theme.Regression,
+				FillColor:   c.Theme.Regression.WithAlpha(32),
+				FontColor:   c.Theme.Text,
+				FontSize:    c.FontSize,
+			},
+			Levels: levels,
+		}
+		if !c.isBoxMode() && len(c.tickerData) > 0 {
+			level.XValue = chartutil.Time.ToFloat64(c.tickerData[0].TimestampUTC)
+		}
+		series = append(series, level)
+	}
+	return series
+}
+
+// getEventSeries returns the event markers for the ticker, colored by kind.
+func (c *Chart) getEventSeries() core.EventSeries {
+	events := make([]core.Event, len(c.events))
+	for index, event := range c.events {
+		events[index] = core.Event{
+			Timestamp: event.TimestampUTC.In(chartutil.Date.Eastern()),
+			Label:     event.GetLabel(),
+			Style:     chart.Style{StrokeColor: c.getEventColor(event.Kind)},
+		}
+	}
+	return core.EventSeries{
+		Name: fmt.Sprintf("%s Events", c.Ticker),
+		Style: c.getAnnotationStyle(chart.Style{
+			Show:        true,
+			StrokeColor: c.Theme.Axis,
+		}),
+		Events: events,
+	}
+}
+
+// getEventColor returns the marker color for a kind of event.
+func (c *Chart) getEventColor(kind string) drawing.Color {
+	switch kind {
+	case model.EquityEventKindEarnings:
+		return c.Theme.ExponentialMovingAverage
+	case model.EquityEventKindDividend:
+		return c.Theme.Up
+	case model.EquityEventKindSplit:
+		return c.Theme.MovingAverage
+	}
+	return c.Theme.Axis
+}
+
+func (c *Chart) getDrawdownSeries(ticker string, data []model.EquityPrice, index int) chart.TimeSeries {
+	xvalues, yvalues := model.EquityPrices(data).Drawdowns()
+	stroke := c.Theme.GetSeriesColor(index)
+	return chart.TimeSeries{
+		Name: fmt.Sprintf("%s Drawdown", ticker),
+		Style: chart.Style{
+			Show:        true,
+			StrokeColor: stroke,
+			FillColor:   stroke.WithAlpha(c.getFillAlpha()),
+			StrokeWidth: c.LineWidth,
+		},
+		XValues: xvalues,
+		YValues: yvalues,
+	}
+}
+
+func (c *Chart) getMaxDrawdownSeries(ticker string, dd model.Drawdown, style chart.Style) chart.Series {
+	style = c.getAnnotationStyle(style)
+
+	recovery := "not recovered"
+	if dd.IsRecovered() {
+		recovery = fmt.Sprintf("recovered in %dd", int(dd.RecoveryDuration().Hours()/24))
+	}
+	label := fmt.Sprintf("Max %s on %s, %s", c.YValueFormatter(dd.Depth()), dd.Trough.TimestampUTC.Format(chart.DefaultDateFormat), recovery)
+	if !c.ShowLegend {
+		label = ticker + " " + label
+	}
+
+	return chart.AnnotationSeries{
+		Name:  fmt.Sprintf("%s - Max Drawdown", ticker),
+		Style: style,
+		Annotations: []chart.Value2{
+			{XValue: chartutil.Time.ToFloat64(dd.Trough.TimestampUTC), YValue: dd.Depth(), Label: label},
+		},
+	}
 }
 
 func (c *Chart) getLastValueSeries(ticker string, priceSeries chart.FullValuesProvider) chart.Series {
@@ -431,18 +1646,13 @@ func (c *Chart) getLastValueSeries(ticker string, priceSeries chart.FullValuesPr
 	}
 
 	yaxis := chart.YAxisPrimary
-	if util.String.CaseInsensitiveEquals(ticker, c.TickerCompare) {
-		if !c.UsePercentageDifferences {
-			yaxis = chart.YAxisSecondary
-		}
-	}
-
 	var style chart.Style
 	if typed, isSeries := priceSeries.(chart.Series); isSeries {
+		yaxis = typed.GetYAxis()
 		style = typed.GetStyle()
 	}
 	style.Show = c.ShowLastValue
-	style.FillColor = drawing.ColorWhite
+	style = c.getAnnotationStyle(style)
 
 	labelText := c.YValueFormatter(lvy)
 	if !c.ShowLegend {
@@ -467,7 +1677,7 @@ func (c *Chart) getBoundedLastValueSeries(ticker string, priceSeries chart.FullB
 		style = s.GetStyle()
 	}
 	style.Show = c.ShowLastValue
-	style.FillColor = drawing.ColorWhite
+	style = c.getAnnotationStyle(style)
 
 	label1 := fmt.Sprintf("%s +%0.0fσ %s", ticker, c.K, c.YValueFormatter(lvy1))
 	if c.ShowLegend {
@@ -493,7 +1703,8 @@ func (c *Chart) getSMASeries(ticker string, priceSeries chart.ValuesProvider) *c
 		Name: fmt.Sprintf("%s SMA", ticker),
 		Style: chart.Style{
 			Show:            c.AddSimpleMovingAverage,
-			StrokeColor:     drawing.ColorRed,
+			StrokeColor:     c.Theme.MovingAverage,
+			StrokeWidth:     c.LineWidth,
 			StrokeDashArray: []float64{5.0, 5.0},
 		},
 		InnerSeries: priceSeries,
@@ -506,7 +1717,8 @@ func (c *Chart) getEMASeries(ticker string, priceSeries chart.ValuesProvider) *c
 		Name: fmt.Sprintf("%s EMA", ticker),
 		Style: chart.Style{
 			Show:            c.AddExponentialMovingAverage,
-			StrokeColor:     drawing.ColorBlue,
+			StrokeColor:     c.Theme.ExponentialMovingAverage,
+			StrokeWidth:     c.LineWidth,
 			StrokeDashArray: []float64{5.0, 5.0},
 		},
 		InnerSeries: priceSeries,
@@ -519,10 +1731,10 @@ func (c *Chart) getBBSeries(ticker string, data []model.EquityPrice) *chart.Boll
 		Name: fmt.Sprintf("%s Bol. Bands", ticker),
 		Style: chart.Style{
 			Show:        c.AddBollingerBands,
-			StrokeColor: drawing.ColorFromHex("efefef"),
-			FillColor:   drawing.ColorFromHex("efefef").WithAlpha(100),
+			StrokeColor: c.Theme.Bands,
+			FillColor:   c.Theme.Bands.WithAlpha(100),
 		},
-		InnerSeries: c.getPriceSeries(ticker, data),
+		InnerSeries: c.getPriceSeries(ticker, data, 0),
 		Period:      c.MAPeriod,
 	}
 }
@@ -532,12 +1744,12 @@ func (c *Chart) getMACDHistogramSeries(ticker string, data []model.EquityPrice)
 		Name: fmt.Sprintf("%s - MACD Div.", ticker),
 		Style: chart.Style{
 			Show:        c.showMACD(),
-			StrokeColor: drawing.ColorGreen,
-			FillColor:   drawing.ColorGreen,
+			StrokeColor: c.Theme.Up,
+			FillColor:   c.Theme.Up,
 		},
 		YAxis: chart.YAxisSecondary,
 		InnerSeries: &chart.MACDSeries{
-			InnerSeries: c.getPriceSeries(ticker, data),
+			InnerSeries: c.getPriceSeries(ticker, data, 0),
 		},
 	}
 }
@@ -547,10 +1759,10 @@ func (c *Chart) getMACDSignalSeries(ticker string, data []model.EquityPrice) *ch
 		Name: fmt.Sprintf("%s - MACD EMA", ticker),
 		Style: chart.Style{
 			Show:        c.showMACD(),
-			StrokeColor: drawing.ColorRed,
+			StrokeColor: c.Theme.MovingAverage,
 		},
 		YAxis:       chart.YAxisSecondary,
-		InnerSeries: c.getPriceSeries(ticker, data),
+		InnerSeries: c.getPriceSeries(ticker, data, 0),
 	}
 }
 
@@ -559,10 +1771,10 @@ func (c *Chart) getMACDLineSeries(ticker string, data []model.EquityPrice) *char
 		Name: fmt.Sprintf("%s - MACD", ticker),
 		Style: chart.Style{
 			Show:        c.showMACD(),
-			StrokeColor: drawing.ColorBlue,
+			StrokeColor: c.Theme.ExponentialMovingAverage,
 		},
 		YAxis:       chart.YAxisSecondary,
-		InnerSeries: c.getPriceSeries(ticker, data),
+		InnerSeries: c.getPriceSeries(ticker, data, 0),
 	}
 }
 
@@ -575,8 +1787,8 @@ func (c *Chart) getLinRegSeries(ticker string, priceSeries chart.ValuesProvider)
 		Name: fmt.Sprintf("%s Lin. Reg.", ticker),
 		Style: chart.Style{
 			Show:            c.AddLinReg,
-			StrokeColor:     drawing.ColorFromHex("FFA500"),
-			StrokeWidth:     2.0,
+			StrokeColor:     c.Theme.Regression,
+			StrokeWidth:     2 * c.LineWidth,
 			StrokeDashArray: []float64{5.0, 5.0},
 		},
 		InnerSeries: priceSeries,
@@ -594,8 +1806,8 @@ func (c *Chart) getPolyRegSeries(ticker string, priceSeries chart.ValuesProvider
 		Name: fmt.Sprintf("%s Poly. Reg.", ticker),
 		Style: chart.Style{
 			Show:            c.AddPolyReg,
-			StrokeColor:     drawing.ColorFromHex("FFA500"),
-			StrokeWidth:     2.0,
+			StrokeColor:     c.Theme.Regression,
+			StrokeWidth:     2 * c.LineWidth,
 			StrokeDashArray: []float64{5.0, 5.0},
 		},
 		InnerSeries: priceSeries,
@@ -605,22 +1817,49 @@ func (c *Chart) getPolyRegSeries(ticker string, priceSeries chart.ValuesProvider
 	}
 }
 
-func (c *Chart) getCandleSeries(ticker string) *chart.CandlestickSeries {
-	var candleValues []chart.CandleValue
-	for _, price := range c.tickerData {
-		if price.IsHistorical {
-			candleValues = append(candleValues, chart.CandleValue{
-				Timestamp: price.TimestampUTC.In(chartutil.Date.Eastern()),
-				Open:      price.Open,
-				Close:     price.Close,
-				High:      price.High,
-				Low:       price.Low,
-			})
+func (c *Chart) getCandleSeries(ticker string) chart.Series {
+	bars := model.EquityPrices(c.tickerData).Historical()
+	name := fmt.Sprintf("%s Candlestick", ticker)
+	if c.CandleType == candleTypeHeikinAshi {
+		bars = bars.HeikinAshi()
+		name = fmt.Sprintf("%s Heikin-Ashi", ticker)
+	}
+
+	candleValues := make([]chart.CandleValue, len(bars))
+	for index, bar := range bars {
+		candleValues[index] = chart.CandleValue{
+			Timestamp: bar.TimestampUTC.In(chartutil.Date.Eastern()),
+			Open:      bar.Open,
+			Close:     bar.Close,
+			High:      bar.High,
+			Low:       bar.Low,
 		}
 	}
 
+	if c.CandleType == candleTypeOHLC {
+		return &core.OHLCSeries{
+			Name: fmt.Sprintf("%s OHLC", ticker),
+			Style: chart.Style{
+				Show: c.AddCandlestick,
+			},
+			CandleValues: candleValues,
+			UpColor:      c.Theme.Up,
+			DownColor:    c.Theme.Down,
+		}
+	}
+	if c.CandleFill == candleFillHollow {
+		return &core.HollowCandlestickSeries{
+			Name: name,
+			Style: chart.Style{
+				Show: c.AddCandlestick,
+			},
+			CandleValues: candleValues,
+			UpColor:      c.Theme.Up,
+			DownColor:    c.Theme.Down,
+		}
+	}
 	return &chart.CandlestickSeries{
-		Name: fmt.Sprintf("%s Candlestick", ticker),
+		Name: name,
 		Style: chart.Style{
 			Show: c.AddCandlestick,
 		},
@@ -629,21 +1868,213 @@ func (c *Chart) getCandleSeries(ticker string) *chart.CandlestickSeries {
 }
 
 func (c *Chart) showMACD() bool {
-	return c.AddMACD && !(c.hasCompare() && !c.UsePercentageDifferences)
+	return c.AddMACD && !c.isDrawdown() && !c.isBoxMode() && !(c.hasCompare() && c.compareOnSecondaryAxis())
 }
 
 func (c *Chart) hasCompare() bool {
-	return len(c.TickerCompare) > 0
+	return len(c.TickersCompare) > 0
+}
+
+func (c *Chart) hasBenchmark() bool {
+	return len(c.TickerBenchmark) > 0
+}
+
+func (c *Chart) hasVolatility() bool {
+	return c.VolatilityWindow > 0
+}
+
+func (c *Chart) subPanelCount() int {
+	var count int
+	if c.hasVolatility() {
+		count++
+	}
+	if c.hasBenchmark() {
+		count++
+	}
+	return count
+}
+
+func (c *Chart) getHeaderHeight() int {
+	if c.ShowTitle {
+		return core.DefaultHeaderHeight
+	}
+	return 0
+}
+
+func (c *Chart) getSubPanelHeight() int {
+	return c.Height / 4
+}
+
+func (c *Chart) isRatio() bool {
+	return c.Mode == chartModeRatio
+}
+
+func (c *Chart) isDrawdown() bool {
+	return c.Mode == chartModeDrawdown
+}
+
+// isBoxMode returns if the chart plots renko bricks or point & figure columns, which ignore time.
+func (c *Chart) isBoxMode() bool {
+	return c.Mode == chartModeRenko || c.Mode == chartModePointAndFigure
+}
+
+// isTextFormat returns if the chart is drawn as text for a terminal.
+func (c *Chart) isTextFormat() bool {
+	return util.String.CaseInsensitiveEquals(c.Format, "txt") || util.String.CaseInsensitiveEquals(c.Format, "ansi")
+}
+
+// IsDataFormat returns if the chart's series are returned as data (csv or json) rather than drawn.
+func (c *Chart) IsDataFormat() bool {
+	return util.String.CaseInsensitiveEquals(c.Format, "csv") || util.String.CaseInsensitiveEquals(c.Format, "json")
+}
+
+// isPNGFormat returns if the chart is drawn as a png.
+func (c *Chart) isPNGFormat() bool {
+	return util.String.CaseInsensitiveEquals(c.Format, "png")
+}
+
+// isGIFFormat returns if the chart is animated as a gif.
+func (c *Chart) isGIFFormat() bool {
+	return util.String.CaseInsensitiveEquals(c.Format, "gif")
+}
+
+// getXRangeMax returns the end of the time axis; the last timestamp, unless the axis is extended.
+func (c *Chart) getXRangeMax() time.Time {
+	if !c.xrangeMax.IsZero() {
+		return c.xrangeMax
+	}
+	return model.EquityPrices(c.tickerData).Last().TimestampUTC
+}
+
+// isHTMLFormat returns if the chart is embedded in an interactive html page.
+func (c *Chart) isHTMLFormat() bool {
+	return util.String.CaseInsensitiveEquals(c.Format, "html")
+}
+
+// IsVegaLiteFormat returns if the chart is returned as a vega-lite spec to be rendered by the client.
+func (c *Chart) IsVegaLiteFormat() bool {
+	return util.String.CaseInsensitiveEquals(c.Format, "vegalite")
+}
+
+// compareOnSecondaryAxis returns if the comparison ticker is plotted against its own axis,
+// which is the case when the two series are in different units (i.e. raw prices).
+func (c *Chart) compareOnSecondaryAxis() bool {
+	return !c.UsePercentageDifferences && !c.isDrawdown()
 }
 
 func (c *Chart) showSecondaryAxis() bool {
-	return c.ShowAxes && !c.UsePercentageDifferences && (c.hasCompare() || c.showMACD())
+	return c.ShowAxes && ((c.hasCompare() && c.compareOnSecondaryAxis()) || (!c.UsePercentageDifferences && c.showMACD()))
+}
+
+// getPriceRange returns the range for the primary (price) axis, or nil to let the chart pick one.
+func (c *Chart) getPriceRange() chart.Range {
+	if c.UseLogScale {
+		return &core.LogRange{}
+	}
+	return nil
+}
+
+// getSecondaryRange returns the range for the secondary axis, which only follows the
+// price scale when it carries the comparison ticker's prices (and not MACD).
+func (c *Chart) getSecondaryRange() chart.Range {
+	if c.UseLogScale && c.hasCompare() {
+		return &core.LogRange{}
+	}
+	return nil
 }
 
 func (c *Chart) getPriceSeriesColors(index int) (stroke, fill drawing.Color) {
-	stroke = chart.GetDefaultColor(index)
-	if !c.AddBollingerBands {
-		fill = stroke.WithAlpha(64)
+	stroke = c.Theme.GetSeriesColor(index)
+	// fills turn to mud once more than two series overlap.
+	if !c.AddBollingerBands && len(c.TickersCompare) < 2 {
+		fill = stroke.WithAlpha(c.getFillAlpha())
 	}
 	return
 }
+
+// getAnnotationStyle returns a series style for its value annotations, filled and lettered with the theme colors.
+func (c *Chart) getAnnotationStyle(style chart.Style) chart.Style {
+	style.FillColor = c.Theme.AnnotationFill
+	style.FontColor = c.Theme.Text
+	style.FontSize = c.FontSize
+	return style
+}
+
+// getFillAlpha returns the alpha for the fills beneath price series.
+func (c *Chart) getFillAlpha() uint8 {
+	return uint8(c.FillOpacity*255 + 0.5)
+}
+
+// getFontSize returns the font size override, or the default if there isn't one.
+func (c *Chart) getFontSize(defaultValue float64) float64 {
+	if c.FontSize > 0 {
+		return c.FontSize
+	}
+	return defaultValue
+}
+
+// getLegend returns the legend for a chart (or sub-panel) in the theme colors.
+func (c *Chart) getLegend(graph *chart.Chart) chart.Renderable {
+	return chart.Legend(graph, chart.Style{
+		FillColor:   c.Theme.AnnotationFill,
+		FontColor:   c.Theme.Text,
+		FontSize:    c.getFontSize(defaultLegendFontSize),
+		StrokeColor: c.Theme.Axis,
+	})
+}
+
+// ratioValueFormatter formats ratios, which are often well below 1.
+func ratioValueFormatter(v interface{}) string {
+	return chart.FloatValueFormatterWithFormat(v, "%.4f")
+}
+
+// d3Formats are the d3 equivalents of the value formatters, for formatting axes on the client.
+var d3Formats = []struct {
+	formatter chart.ValueFormatter
+	format    string
+}{
+	{chart.TimeValueFormatter, "%Y-%m-%d"},
+	{chart.TimeDateValueFormatter, "%Y-%m-%d"},
+	{chart.TimeHourValueFormatter, "%m-%d %-I%p"},
+	{chart.TimeMinuteValueFormatter, "%m-%d %-I:%M%p"},
+	{chart.FloatValueFormatter, ".2f"},
+	{chart.PercentValueFormatter, ".2%"},
+	{ratioValueFormatter, ".4f"},
+}
+
+// getD3Format returns the d3 format equivalent to a value formatter, or the default if there isn't one.
+func getD3Format(vf chart.ValueFormatter, defaultFormat string) string {
+	if vf == nil {
+		return defaultFormat
+	}
+	for _, d3Format := range d3Formats {
+		if reflect.ValueOf(d3Format.formatter).Pointer() == reflect.ValueOf(vf).Pointer() {
+			return d3Format.format
+		}
+	}
+	return defaultFormat
+}
+
+// parseStyleFloat reads a numeric style query value, failing (rather than falling back to the default) if it isn't a number.
+func parseStyleFloat(rc *web.Ctx, key string, defaultValue float64) (float64, error) {
+	value := core.ReadQueryValue(rc, key, "")
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	return parsed, nil
+}
+
+// parseTickers splits a comma delimited list of tickers, dropping empty entries.
+func parseTickers(value string) []string {
+	var tickers []string
+	for _, ticker := range strings.Split(value, ",") {
+		if ticker = strings.TrimSpace(ticker); len(ticker) > 0 {
+			tickers = append(tickers, ticker)
+		}
+	}
+	return tickers
+}
@@ -0,0 +1,410 @@
+package viewmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/blendlabs/go-web"
+	"github.com/wcharczuk/chart-service/server/core"
+)
+
+// ChartSpec is a chart described as a json document, posted to `/stock/chart`. It's an alternative to
+// the query string for charts with many options; each field maps onto a query parameter of the same chart.
+type ChartSpec struct {
+	Ticker      string                `json:"ticker"`
+	Timeframe   string                `json:"timeframe"`
+	Mode        string                `json:"mode"`
+	Format      string                `json:"format"`
+	Theme       string                `json:"theme"`
+	Title       string                `json:"title"`
+	Size        *ChartSpecSize        `json:"size"`
+	Frames      *int                  `json:"frames"`
+	Compare     []string              `json:"compare"`
+	Versus      string                `json:"vs"`
+	Join        string                `json:"join"`
+	Interval    string                `json:"interval"`
+	Scale       string                `json:"scale"`
+	UsePercent  *bool                 `json:"use_pct"`
+	Show        *ChartSpecShow        `json:"show"`
+	Style       *ChartSpecStyle       `json:"style"`
+	Indicators  *ChartSpecIndicators  `json:"indicators"`
+	Candles     *ChartSpecCandles     `json:"candles"`
+	Box         *ChartSpecBox         `json:"box"`
+	Panels      *ChartSpecPanels      `json:"panels"`
+	Annotations *ChartSpecAnnotations `json:"annotations"`
+}
+
+// ChartSpecSize is the image size in pixels, or in characters for text formats.
+type ChartSpecSize struct {
+	Width   *int     `json:"width"`
+	Height  *int     `json:"height"`
+	Columns *int     `json:"cols"`
+	Rows    *int     `json:"rows"`
+	Scale   *float64 `json:"scale"`
+}
+
+// ChartSpecShow toggles the parts of the chart.
+type ChartSpecShow struct {
+	Axes   *bool `json:"axes"`
+	Grid   *bool `json:"grid"`
+	Last   *bool `json:"last"`
+	Legend *bool `json:"legend"`
+	Title  *bool `json:"title"`
+	Events *bool `json:"events"`
+}
+
+// ChartSpecStyle overrides the theme.
+type ChartSpecStyle struct {
+	Colors      []string  `json:"colors"`
+	LineWidth   *float64  `json:"line_width"`
+	FillOpacity *float64  `json:"fill_opacity"`
+	FontSize    *float64  `json:"font_size"`
+	GridDash    []float64 `json:"grid_dash"`
+	Padding     []int     `json:"padding"`
+}
+
+// ChartSpecIndicators are the indicators drawn over the prices, and their parameters.
+type ChartSpecIndicators struct {
+	SMA     *bool    `json:"sma"`
+	EMA     *bool    `json:"ema"`
+	BB      *bool    `json:"bb"`
+	MACD    *bool    `json:"macd"`
+	LinReg  *bool    `json:"linreg"`
+	PolyReg *bool    `json:"polyreg"`
+	Period  *int     `json:"period"`
+	K       *float64 `json:"k"`
+	Degree  *int     `json:"degree"`
+	Window  *int     `json:"window"`
+	Offset  *int     `json:"offset"`
+}
+
+// ChartSpecCandles draws the prices as bars.
+type ChartSpecCandles struct {
+	Type string `json:"type"`
+	Fill string `json:"fill"`
+}
+
+// ChartSpecBox sets the renko and point & figure parameters; the box size defaults to the average true range.
+type ChartSpecBox struct {
+	Size     *float64 `json:"size"`
+	Reversal *int     `json:"reversal"`
+}
+
+// ChartSpecPanels are the panels drawn beneath the chart.
+type ChartSpecPanels struct {
+	Volatility *ChartSpecVolatility `json:"volatility"`
+	Beta       *ChartSpecBeta       `json:"beta"`
+}
+
+// ChartSpecVolatility is the rolling volatility panel.
+type ChartSpecVolatility struct {
+	Window int `json:"window"`
+}
+
+// ChartSpecBeta is the rolling beta panel.
+type ChartSpecBeta struct {
+	Versus string `json:"vs"`
+	Window *int   `json:"window"`
+}
+
+// ChartSpecAnnotations are the reference lines, bands and shaded periods.
+type ChartSpecAnnotations struct {
+	Lines   []ChartSpecLine   `json:"lines"`
+	Bands   []ChartSpecBand   `json:"bands"`
+	Periods []ChartSpecPeriod `json:"periods"`
+}
+
+// ChartSpecLine is a reference line.
+type ChartSpecLine struct {
+	Value float64 `json:"value"`
+	Label string  `json:"label"`
+}
+
+// ChartSpecBand is a shaded price band.
+type ChartSpecBand struct {
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	Label string  `json:"label"`
+}
+
+// ChartSpecPeriod is a shaded date range; both days are formatted `2006-01-02` and included.
+type ChartSpecPeriod struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Label string `json:"label"`
+}
+
+var (
+	chartSpecDate  = core.StringSchema("a date formatted `2006-01-02`")
+	chartSpecLabel = core.StringSchema("the label")
+	chartSpecPrice = core.NumberSchema("the price, or percent change as a fraction", -1e9, 1e9)
+)
+
+// ChartSpecSchema is the schema chart specs are validated against; it's served at `/api/v1/chart.schema`.
+var ChartSpecSchema = core.ObjectSchema("a stock chart", map[string]*core.Schema{
+	"ticker":    core.StringSchema("the ticker to chart"),
+	"timeframe": core.StringSchema("the period to chart", chartTimeframes...),
+	"mode":      core.StringSchema("what to plot", chartModePrice, chartModeDrawdown, chartModeRatio, chartModeRenko, chartModePointAndFigure),
+	"format":    core.StringSchema("the image format; csv or json for the plotted values, vegalite for a vega-lite spec, html for an interactive page or gif for an animation", chartFormats...),
+	"theme":     core.StringSchema("the theme name"),
+	"title":     core.StringSchema("the title shown above the chart"),
+	"size": core.ObjectSchema("the image size in pixels, or in characters for text formats", map[string]*core.Schema{
+		"width":  core.IntegerSchema("the width", minChartSize, maxChartSize),
+		"height": core.IntegerSchema("the height", minChartSize, maxChartSize),
+		"cols":   core.IntegerSchema("the width of a text chart", minTextColumns, maxTextColumns),
+		"rows":   core.IntegerSchema("the height of a text chart", minTextRows, maxTextRows),
+		"scale":  core.NumberSchema("the pixel density of png and gif images, i.e. 2 for retina displays", minScale, maxScale),
+	}),
+	"frames":   core.IntegerSchema("the number of frames of a gif", minFrames, maxFrames),
+	"compare":  core.ArraySchema("tickers to compare against", core.StringSchema("a ticker"), maxCompareTickers),
+	"vs":       core.StringSchema("the benchmark ticker for ratio mode"),
+	"join":     core.StringSchema("how to align prices across tickers", "intersect", "fill_forward", "ffill"),
+	"interval": core.StringSchema("the bar interval", "1d", "1w", "1wk", "1mo"),
+	"scale":    core.StringSchema("the price axis scale", "linear", "log"),
+	"use_pct":  core.BooleanSchema("plot percent change rather than price"),
+	"show": core.ObjectSchema("the parts of the chart to show", map[string]*core.Schema{
+		"axes":   core.BooleanSchema("show the axes"),
+		"grid":   core.BooleanSchema("show the grid"),
+		"last":   core.BooleanSchema("show the last value"),
+		"legend": core.BooleanSchema("show the legend"),
+		"title":  core.BooleanSchema("show the title header"),
+		"events": core.BooleanSchema("show earnings, dividends, splits and notes"),
+	}),
+	"style": core.ObjectSchema("overrides for the theme", map[string]*core.Schema{
+		"colors":       core.ArraySchema("the series colors, as hex", core.StringSchema("a hex color"), 0),
+		"line_width":   core.NumberSchema("the series line width", 0.1, 20),
+		"fill_opacity": core.NumberSchema("the fill opacity", 0, 1),
+		"font_size":    core.NumberSchema("the font size", 0, 72),
+		"grid_dash":    core.ArraySchema("the grid dash and gap lengths; empty is solid", core.NumberSchema("a length", 0.1, 100), 8),
+		"padding":      core.ArraySchema("the padding, as one value or four in css order", core.IntegerSchema("a length", 1, 1000), 4),
+	}),
+	"indicators": core.ObjectSchema("indicators drawn over the prices", map[string]*core.Schema{
+		"sma":     core.BooleanSchema("add a simple moving average"),
+		"ema":     core.BooleanSchema("add an exponential moving average"),
+		"bb":      core.BooleanSchema("add bollinger bands"),
+		"macd":    core.BooleanSchema("add a macd histogram"),
+		"linreg":  core.BooleanSchema("add a linear regression"),
+		"polyreg": core.BooleanSchema("add a polynomial regression"),
+		"period":  core.IntegerSchema("the moving average period", 2, 5000),
+		"k":       core.NumberSchema("the bollinger band width in standard deviations", 0.1, 10),
+		"degree":  core.IntegerSchema("the polynomial regression degree", 1, 10),
+		"window":  core.IntegerSchema("the number of bars the regressions fit", 2, 5000),
+		"offset":  core.IntegerSchema("the first bar the regressions fit", 0, 5000),
+	}),
+	"candles": core.ObjectSchema("draw the prices as bars", map[string]*core.Schema{
+		"type": core.StringSchema("the bar type", candleTypeCandlestick, candleTypeHeikinAshi, candleTypeOHLC),
+	}),
+	"box": core.ObjectSchema("renko and point & figure parameters", map[string]*core.Schema{
+		"size":     core.NumberSchema("the box size; defaults to the average true range", 0.0001, 1e9),
+		"reversal": core.IntegerSchema("the point & figure reversal in boxes", 1, 100),
+	}),
+	"panels": core.ObjectSchema("panels drawn beneath the chart", map[string]*core.Schema{
+		"volatility": core.ObjectSchema("rolling volatility", map[string]*core.Schema{
+			"window": core.IntegerSchema("the window in days", 2, 5000),
+		}, "window"),
+		"beta": core.ObjectSchema("rolling beta", map[string]*core.Schema{
+			"vs":     core.StringSchema("the benchmark ticker"),
+			"window": core.IntegerSchema("the window in days", 2, 5000),
+		}, "vs"),
+	}),
+	"annotations": core.ObjectSchema("reference lines, bands and shaded periods", map[string]*core.Schema{
+		"lines": core.ArraySchema("horizontal reference lines", core.ObjectSchema("a line", map[string]*core.Schema{
+			"value": chartSpecPrice,
+			"label": chartSpecLabel,
+		}, "value"), maxReferences),
+		"bands": core.ArraySchema("shaded price bands", core.ObjectSchema("a band", map[string]*core.Schema{
+			"low":   chartSpecPrice,
+			"high":  chartSpecPrice,
+			"label": chartSpecLabel,
+		}, "low", "high"), maxReferences),
+		"periods": core.ArraySchema("shaded date ranges", core.ObjectSchema("a period", map[string]*core.Schema{
+			"start": chartSpecDate,
+			"end":   chartSpecDate,
+			"label": chartSpecLabel,
+		}, "start", "end"), maxReferences),
+	}),
+}, "ticker")
+
+// ParseChartSpec validates a json chart spec against the schema and reads it.
+func ParseChartSpec(body []byte) (*ChartSpec, error) {
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("invalid chart spec: %s", err.Error())
+	}
+	if err := ChartSpecSchema.Validate(document); err != nil {
+		return nil, err
+	}
+	var spec ChartSpec
+	if err := json.Unmarshal(body, &spec); err != nil {
+		return nil, fmt.Errorf("invalid chart spec: %s", err.Error())
+	}
+	return &spec, nil
+}
+
+// RouteParameters returns the route values of the equivalent `GET /stock/chart/:ticker/:timeframe` request.
+func (cs *ChartSpec) RouteParameters() web.RouteParameters {
+	params := web.RouteParameters{"ticker": cs.Ticker}
+	if len(cs.Timeframe) > 0 {
+		params["timeframe"] = cs.Timeframe
+	}
+	return params
+}
+
+// Query returns the query string of the equivalent `GET /stock/chart/:ticker/:timeframe` request.
+func (cs *ChartSpec) Query() url.Values {
+	query := url.Values{}
+	setQueryString(query, "mode", cs.Mode)
+	setQueryString(query, "format", cs.Format)
+	setQueryString(query, "theme", cs.Theme)
+	setQueryString(query, "title", cs.Title)
+	if len(cs.Compare) > 0 {
+		query.Set("compare", strings.Join(cs.Compare, ","))
+	}
+	setQueryString(query, "vs", cs.Versus)
+	setQueryString(query, "join", cs.Join)
+	setQueryString(query, "interval", cs.Interval)
+	setQueryString(query, "scale", cs.Scale)
+	setQueryBool(query, "use_pct", cs.UsePercent)
+
+	if size := cs.Size; size != nil {
+		setQueryInt(query, "width", size.Width)
+		setQueryInt(query, "height", size.Height)
+		setQueryInt(query, "cols", size.Columns)
+		setQueryInt(query, "rows", size.Rows)
+		// the pixel density shares `scale` with the axis scale.
+		if size.Scale != nil {
+			query.Add("scale", formatFloat(*size.Scale))
+		}
+	}
+	setQueryInt(query, "frames", cs.Frames)
+	if show := cs.Show; show != nil {
+		setQueryBool(query, "show_axes", show.Axes)
+		setQueryBool(query, "show_grid", show.Grid)
+		setQueryBool(query, "show_last", show.Last)
+		setQueryBool(query, "show_legend", show.Legend)
+		setQueryBool(query, "show_title", show.Title)
+		setQueryBool(query, "show_events", show.Events)
+	}
+	if style := cs.Style; style != nil {
+		if len(style.Colors) > 0 {
+			query.Set("color", strings.Join(style.Colors, ","))
+		}
+		setQueryFloat(query, "line_width", style.LineWidth)
+		setQueryFloat(query, "fill_opacity", style.FillOpacity)
+		setQueryFloat(query, "font_size", style.FontSize)
+		if style.GridDash != nil {
+			if len(style.GridDash) == 0 {
+				query.Set("grid_dash", "solid")
+			} else {
+				query.Set("grid_dash", joinFloats(style.GridDash))
+			}
+		}
+		if len(style.Padding) > 0 {
+			padding := make([]string, len(style.Padding))
+			for index, value := range style.Padding {
+				padding[index] = strconv.Itoa(value)
+			}
+			query.Set("padding", strings.Join(padding, ","))
+		}
+	}
+	if indicators := cs.Indicators; indicators != nil {
+		setQueryBool(query, "add_sma", indicators.SMA)
+		setQueryBool(query, "add_ema", indicators.EMA)
+		setQueryBool(query, "add_bb", indicators.BB)
+		setQueryBool(query, "add_macd", indicators.MACD)
+		setQueryBool(query, "add_linreg", indicators.LinReg)
+		setQueryBool(query, "add_polyreg", indicators.PolyReg)
+		setQueryInt(query, "period", indicators.Period)
+		setQueryFloat(query, "k", indicators.K)
+		setQueryInt(query, "degree", indicators.Degree)
+		setQueryInt(query, "limit", indicators.Window)
+		setQueryInt(query, "offset", indicators.Offset)
+	}
+	if candles := cs.Candles; candles != nil {
+		query.Set("add_candle", "true")
+		setQueryString(query, "candle_type", candles.Type)
+		setQueryString(query, "candle_fill", candles.Fill)
+	}
+	if box := cs.Box; box != nil {
+		setQueryFloat(query, "box", box.Size)
+		setQueryInt(query, "reversal", box.Reversal)
+	}
+	if panels := cs.Panels; panels != nil {
+		if volatility := panels.Volatility; volatility != nil {
+			query.Set("add_vol", strconv.Itoa(volatility.Window))
+		}
+		if beta := panels.Beta; beta != nil {
+			query.Set("beta_vs", beta.Versus)
+			setQueryInt(query, "beta_window", beta.Window)
+		}
+	}
+	if annotations := cs.Annotations; annotations != nil {
+		for _, line := range annotations.Lines {
+			query.Add("hline", withLabel(formatFloat(line.Value), line.Label))
+		}
+		for _, band := range annotations.Bands {
+			query.Add("band", withLabel(formatFloat(band.Low)+"-"+formatFloat(band.High), band.Label))
+		}
+		for _, period := range annotations.Periods {
+			query.Add("vband", withLabel(period.Start+".."+period.End, period.Label))
+		}
+	}
+	return query
+}
+
+// Ctx returns a request context for the equivalent `GET /stock/chart/:ticker/:timeframe` request, so the spec
+// is parsed by the same pipeline. The response is shared with the original context.
+func (cs *ChartSpec) Ctx(rc *web.Ctx) *web.Ctx {
+	req := &http.Request{}
+	*req = *rc.Request
+	req.Method = http.MethodGet
+	req.URL = &url.URL{Path: rc.Request.URL.Path, RawQuery: cs.Query().Encode()}
+	return web.NewCtx(rc.Response, req, cs.RouteParameters()).WithApp(rc.App())
+}
+
+func setQueryString(query url.Values, key, value string) {
+	if len(value) > 0 {
+		query.Set(key, value)
+	}
+}
+
+func setQueryBool(query url.Values, key string, value *bool) {
+	if value != nil {
+		query.Set(key, strconv.FormatBool(*value))
+	}
+}
+
+func setQueryInt(query url.Values, key string, value *int) {
+	if value != nil {
+		query.Set(key, strconv.Itoa(*value))
+	}
+}
+
+func setQueryFloat(query url.Values, key string, value *float64) {
+	if value != nil {
+		query.Set(key, formatFloat(*value))
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func joinFloats(values []float64) string {
+	formatted := make([]string, len(values))
+	for index, value := range values {
+		formatted[index] = formatFloat(value)
+	}
+	return strings.Join(formatted, ",")
+}
+
+func withLabel(value, label string) string {
+	if len(label) > 0 {
+		return value + ":" + label
+	}
+	return value
+}
@@ -0,0 +1,68 @@
+package viewmodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blendlabs/go-assert"
+	"github.com/wcharczuk/chart-service/server/core"
+	"github.com/wcharczuk/chart-service/server/equity"
+	"github.com/wcharczuk/chart-service/server/model"
+	"github.com/wcharczuk/go-chart"
+)
+
+func TestChartETag(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2017, 05, 01, 16, 0, 0, 0, time.UTC)
+	c := &Chart{
+		ShowTitle:  true,
+		TickerInfo: &equity.Quote{Timestamp: now, Last: 150.25},
+		tickerData: []model.EquityPrice{{TimestampUTC: now.AddDate(0, 0, -1), Price: 149}},
+	}
+	etag := c.ETag("key")
+	assert.Equal(etag, c.ETag("key"))
+	assert.NotEqual(etag, c.ETag("other"))
+
+	c.TickerInfo = &equity.Quote{Timestamp: now.Add(time.Minute), Last: 150.50}
+	quoted := c.ETag("key")
+	assert.NotEqual(etag, quoted, "a new quote changes the header without a new price")
+
+	c.ShowEvents = true
+	c.events = []model.EquityEvent{{ID: 1, Kind: "note", TimestampUTC: now, Label: "Earnings"}}
+	evented := c.ETag("key")
+	assert.NotEqual(quoted, evented)
+	c.events[0].Label = "Earnings call"
+	assert.NotEqual(evented, c.ETag("key"), "an edited event changes the chart")
+}
+
+func TestChartOverlayLineWidth(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Chart{LineWidth: 3, MAPeriod: 2, Limit: 2, Degree: 2}
+	prices := chart.ContinuousSeries{XValues: []float64{0, 1, 2}, YValues: []float64{1, 2, 3}}
+	assert.Equal(3.0, c.getSMASeries("TEST", prices).GetStyle().StrokeWidth)
+	assert.Equal(3.0, c.getEMASeries("TEST", prices).GetStyle().StrokeWidth)
+	assert.Equal(6.0, c.getLinRegSeries("TEST", prices).GetStyle().StrokeWidth, "regressions are twice the line width")
+	assert.Equal(6.0, c.getPolyRegSeries("TEST", prices).GetStyle().StrokeWidth)
+}
+
+func TestChartCandleSeriesFill(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &Chart{
+		CandleType: candleTypeCandlestick,
+		CandleFill: candleFillFilled,
+		tickerData: []model.EquityPrice{{TimestampUTC: time.Now().UTC(), Open: 10, High: 12, Low: 9, Close: 11, IsHistorical: true}},
+	}
+	_, isFilled := c.getCandleSeries("TEST").(*chart.CandlestickSeries)
+	assert.True(isFilled, "candles are filled unless hollow candles are asked for")
+
+	c.CandleType = candleTypeHeikinAshi
+	_, isFilled = c.getCandleSeries("TEST").(*chart.CandlestickSeries)
+	assert.True(isFilled)
+
+	c.CandleFill = candleFillHollow
+	_, isHollow := c.getCandleSeries("TEST").(*core.HollowCandlestickSeries)
+	assert.True(isHollow)
+}
@@ -0,0 +1,202 @@
+package viewmodel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blendlabs/go-web"
+	"github.com/wcharczuk/chart-service/server/core"
+	"github.com/wcharczuk/chart-service/server/equity"
+	"github.com/wcharczuk/chart-service/server/google"
+	"github.com/wcharczuk/chart-service/server/model"
+	"github.com/wcharczuk/go-chart"
+)
+
+const (
+	defaultGridWidth     = 400
+	defaultGridRowHeight = 30
+
+	// maxGridTickers is the most rows a single grid will render.
+	maxGridTickers = model.MaxWatchlistTickers
+	// maxGridRowHeight is the tallest a grid row can be, so a full grid is at most 5,000 pixels tall.
+	maxGridRowHeight = 100
+
+	gridTickerWidth = 70
+	gridLastWidth   = 80
+	gridChangeWidth = 80
+)
+
+// Grid are the grid parameters; one row per ticker with the last price, the day's change and a sparkline
+// for the period. The tickers are listed directly or come from a saved watchlist.
+type Grid struct {
+	Chart
+
+	Tickers   []string `query:"tickers"`
+	Watchlist string   `query:"watchlist"`
+	RowHeight int      `query:"row_height"`
+	Quotes    []equity.Quote
+
+	tickersData [][]model.EquityPrice
+}
+
+// Parse sets the grid properties from a request context.
+func (g *Grid) Parse(rc *web.Ctx) error {
+	g.Width = core.ReadQueryValueInt(rc, "width", defaultGridWidth)
+	g.RowHeight = core.ReadQueryValueInt(rc, "row_height", defaultGridRowHeight)
+	g.Format = core.ReadQueryValue(rc, "format", "png")
+	theme, err := core.GetTheme(core.ReadQueryValue(rc, "theme", core.ThemeLight))
+	if err != nil {
+		return err
+	}
+	g.Theme = theme
+
+	g.Tickers = parseTickers(core.ReadQueryValue(rc, "tickers", ""))
+	g.Watchlist = core.ReadQueryValue(rc, "watchlist", "")
+	g.ChartTimeframe = core.ReadRouteValue(rc, "timeframe", defaultChartTimeframe)
+	g.YValueFormatter = chart.FloatValueFormatter
+	return nil
+}
+
+// Validate applies some sanity check validation rules.
+func (g *Grid) Validate() error {
+	if len(g.Tickers) == 0 && len(g.Watchlist) == 0 {
+		return errors.New("caller did not specify `tickers` or a `watchlist`, cannot continue")
+	}
+	if len(g.Tickers) > 0 && len(g.Watchlist) > 0 {
+		return errors.New("specify either `tickers` or a `watchlist`, not both")
+	}
+	if len(g.Tickers) > maxGridTickers {
+		return fmt.Errorf("cannot render more than %d tickers", maxGridTickers)
+	}
+	if g.Width <= gridTickerWidth+gridLastWidth+gridChangeWidth {
+		return fmt.Errorf("grid width must be greater than %d", gridTickerWidth+gridLastWidth+gridChangeWidth)
+	}
+	if g.Width > maxChartSize {
+		return fmt.Errorf("grid width must be at most %d", maxChartSize)
+	}
+	if g.RowHeight <= 0 || g.RowHeight > maxGridRowHeight {
+		return fmt.Errorf("row_height must be between 1 and %d", maxGridRowHeight)
+	}
+	if g.isGIFFormat() {
+		return errors.New("gif is only available for charts, as grids aren't animated")
+	}
+	if g.isHTMLFormat() {
+		return errors.New("html is only available for charts, as grids aren't interactive")
+	}
+	if err := validateFormat(g.Format, imageFormats); err != nil {
+		return err
+	}
+	if g.Start.IsZero() {
+		return errors.New("data period start time is unset, cannot continue")
+	}
+	if g.End.IsZero() {
+		return errors.New("data period end time is unset, cannot continue")
+	}
+	return nil
+}
+
+// FetchTickers resolves the watchlist (if any) and fetches the quotes for every ticker in a single request.
+func (g *Grid) FetchTickers() error {
+	if len(g.Watchlist) > 0 {
+		watchlist, err := model.GetWatchlistByName(g.Watchlist)
+		if err != nil {
+			return err
+		}
+		if watchlist.IsZero() {
+			return fmt.Errorf("No watchlist named: %s", g.Watchlist)
+		}
+		g.Tickers = watchlist.GetTickers()
+		if len(g.Tickers) == 0 {
+			return fmt.Errorf("The watchlist %s has no tickers", g.Watchlist)
+		}
+		if len(g.Tickers) > maxGridTickers {
+			return fmt.Errorf("cannot render more than %d tickers", maxGridTickers)
+		}
+	}
+
+	quotes, err := google.GetCurrentPrices(g.Tickers)
+	if err != nil {
+		return err
+	}
+	if len(quotes) != len(g.Tickers) {
+		return fmt.Errorf("No stock info returned for: %#v", g.Tickers)
+	}
+	for index, quote := range quotes {
+		if quote.IsZero() {
+			return fmt.Errorf("No stock information returned for: %s", strings.ToUpper(g.Tickers[index]))
+		}
+		g.Tickers[index] = strings.ToUpper(g.Tickers[index])
+	}
+	g.Quotes = quotes
+	return nil
+}
+
+// FetchPriceData fetches the price data for every ticker.
+func (g *Grid) FetchPriceData() error {
+	useLivePricing, useHistoricalPricing := g.getPricingSources()
+	data, err := GetEquityPricesByDateForTickers(g.Tickers, g.Start, g.End, useLivePricing, useHistoricalPricing)
+	if err != nil {
+		return err
+	}
+	g.tickersData = data
+	return nil
+}
+
+// CreateImage creates the grid for the parameters.
+func (g *Grid) CreateImage() (core.Renderable, error) {
+	layout := core.Layout{
+		Width:      g.Width,
+		Height:     len(g.Tickers) * g.RowHeight,
+		Background: chart.Style{FillColor: g.Theme.Background},
+	}
+	for index, ticker := range g.Tickers {
+		layout.Panels = append(layout.Panels, g.getRowPanels(index*g.RowHeight, ticker, g.Quotes[index], g.tickersData[index])...)
+	}
+	return layout, nil
+}
+
+// getRowPanels returns the cells of a row; the ticker, the last price, the day's change and the sparkline.
+func (g *Grid) getRowPanels(top int, ticker string, quote equity.Quote, data []model.EquityPrice) []core.Panel {
+	changeColor := g.Theme.GetUpDownColor(quote.ChangePCT >= 0)
+	_, values := model.EquityPrices(data).Prices()
+	sparklineColor := g.Theme.GetUpDownColor(len(values) == 0 || values[len(values)-1] >= values[0])
+
+	sparklineLeft := gridTickerWidth + gridLastWidth + gridChangeWidth
+	return []core.Panel{
+		{Top: top, Renderable: core.Label{
+			Width:  gridTickerWidth,
+			Height: g.RowHeight,
+			Text:   ticker,
+			Style:  chart.Style{FontColor: g.Theme.Text},
+		}},
+		{Top: top, Left: gridTickerWidth, Renderable: core.Label{
+			Width:  gridLastWidth,
+			Height: g.RowHeight,
+			Text:   g.YValueFormatter(quote.Last),
+			Style: chart.Style{
+				FontColor:           g.Theme.Text,
+				TextHorizontalAlign: chart.TextHorizontalAlignRight,
+			},
+		}},
+		{Top: top, Left: gridTickerWidth + gridLastWidth, Renderable: core.Label{
+			Width:  gridChangeWidth,
+			Height: g.RowHeight,
+			Text:   fmt.Sprintf("%+.2f%%", quote.ChangePCT),
+			Style: chart.Style{
+				FontColor:           changeColor,
+				TextHorizontalAlign: chart.TextHorizontalAlignRight,
+			},
+		}},
+		{Top: top, Left: sparklineLeft, Renderable: core.Sparkline{
+			Width:  g.Width - sparklineLeft,
+			Height: g.RowHeight,
+			Values: values,
+			Style: chart.Style{
+				StrokeColor: sparklineColor,
+				FillColor:   sparklineColor.WithAlpha(64),
+			},
+			Background: chart.Style{FillColor: g.Theme.Background},
+		}},
+	}
+}
@@ -2,6 +2,7 @@ package viewmodel
 
 import (
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/wcharczuk/chart-service/server/core"
@@ -38,3 +39,27 @@ func GetEquityPricesByDate(ticker string, start, end time.Time, useLocalData, us
 	sort.Sort(model.EquityPrices(union))
 	return union, nil
 }
+
+// GetEquityPricesByDateForTickers gets pricing data for each of the tickers concurrently.
+// The results are in the same order as the tickers.
+func GetEquityPricesByDateForTickers(tickers []string, start, end time.Time, useLocalData, useRemoteData bool) ([][]model.EquityPrice, error) {
+	results := make([][]model.EquityPrice, len(tickers))
+	errs := make([]error, len(tickers))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(tickers))
+	for index, ticker := range tickers {
+		go func(index int, ticker string) {
+			defer wg.Done()
+			results[index], errs[index] = GetEquityPricesByDate(ticker, start, end, useLocalData, useRemoteData)
+		}(index, ticker)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,91 @@
+package viewmodel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blendlabs/go-web"
+	"github.com/wcharczuk/chart-service/server/core"
+	"github.com/wcharczuk/chart-service/server/model"
+	"github.com/wcharczuk/go-chart"
+)
+
+const (
+	defaultSparklineWidth  = 120
+	defaultSparklineHeight = 30
+)
+
+// Sparkline are the sparkline parameters; a tiny price chart with no axes, legend or annotations.
+// It shares the period and price fetching of the full chart.
+type Sparkline struct {
+	Chart
+}
+
+// Parse sets the sparkline properties from a request context.
+func (s *Sparkline) Parse(rc *web.Ctx) error {
+	s.Width = core.ReadQueryValueInt(rc, "width", defaultSparklineWidth)
+	s.Height = core.ReadQueryValueInt(rc, "height", defaultSparklineHeight)
+	s.Format = core.ReadQueryValue(rc, "format", "png")
+	theme, err := core.GetTheme(core.ReadQueryValue(rc, "theme", core.ThemeLight))
+	if err != nil {
+		return err
+	}
+	s.Theme = theme
+
+	s.Ticker = core.ReadRouteValue(rc, "ticker", "")
+	s.ChartTimeframe = core.ReadRouteValue(rc, "timeframe", defaultChartTimeframe)
+	s.ShowLastValue = core.ReadQueryValueBool(rc, "show_last", false)
+	s.YValueFormatter = chart.FloatValueFormatter
+	return nil
+}
+
+// Validate applies some sanity check validation rules.
+func (s *Sparkline) Validate() error {
+	if len(s.Ticker) == 0 {
+		return errors.New("caller did not specify a :ticker parameter, cannot continue")
+	}
+	if s.Width <= 0 || s.Height <= 0 || s.Width > maxChartSize || s.Height > maxChartSize {
+		return fmt.Errorf("sparkline width and height must be between 1 and %d", maxChartSize)
+	}
+	if s.isGIFFormat() {
+		return errors.New("gif is only available for charts, as sparklines aren't animated")
+	}
+	if s.isHTMLFormat() {
+		return errors.New("html is only available for charts, as sparklines aren't interactive")
+	}
+	if err := validateFormat(s.Format, imageFormats); err != nil {
+		return err
+	}
+	if s.Start.IsZero() {
+		return errors.New("data period start time is unset, cannot continue")
+	}
+	if s.End.IsZero() {
+		return errors.New("data period end time is unset, cannot continue")
+	}
+	return nil
+}
+
+// CreateImage creates the sparkline for the parameters, colored by the change over the period.
+func (s *Sparkline) CreateImage() (core.Renderable, error) {
+	_, values := model.EquityPrices(s.tickerData).Prices()
+	if len(values) == 0 {
+		return nil, errors.New("no data")
+	}
+
+	color := s.Theme.GetUpDownColor(values[len(values)-1] >= values[0])
+
+	sparkline := core.Sparkline{
+		Width:  s.Width,
+		Height: s.Height,
+		Values: values,
+		Style: chart.Style{
+			StrokeColor: color,
+			FillColor:   color.WithAlpha(64),
+		},
+		Background: chart.Style{FillColor: s.Theme.Background},
+	}
+	if s.ShowLastValue {
+		sparkline.Label = s.YValueFormatter(values[len(values)-1])
+	}
+	return sparkline, nil
+}